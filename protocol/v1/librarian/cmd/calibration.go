@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+	"github.com/urfave/cli/v2"
+)
+
+var calibrationTrends = map[validator.CalibrationTrend]string{
+	validator.CalibrationConverging: "↘ converging",
+	validator.CalibrationDiverging:  "↗ diverging",
+	validator.CalibrationSteady:     "→ steady",
+}
+
+func calibrationCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "calibration",
+		Usage: "Compare the scores different evaluators gave the same training entries",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository"),
+			&cli.StringFlag{
+				Name:  "dimension",
+				Usage: "Only compare this dimension",
+			},
+			&cli.StringFlag{
+				Name:  "evaluator",
+				Usage: "Only compare this evaluator with the others (name, email or eth)",
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Only compare DSU entries on or after this date (YYYY-MM-DD)",
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: "Only compare DSU entries on or before this date (YYYY-MM-DD)",
+			},
+			&cli.IntFlag{
+				Name:  "top",
+				Usage: "Number of biggest disagreements to show",
+				Value: validator.DefaultDisagreements,
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Output format: text or json",
+				Value:   "text",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			since, err := parseDateFlag(c, "since")
+			if err != nil {
+				return err
+			}
+			until, err := parseDateFlag(c, "until")
+			if err != nil {
+				return err
+			}
+
+			dsuFiles, err := validator.GetDSUFiles(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get DSU files: %s", err)
+			}
+			evaluationFiles, err := validator.GetEvaluationFiles(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get evaluation files: %s", err)
+			}
+
+			calibrations := validator.BuildCalibrations(dsuFiles, evaluationFiles, validator.CalibrationQuery{
+				Dimension:     c.String("dimension"),
+				Evaluator:     c.String("evaluator"),
+				Since:         since,
+				Until:         until,
+				Disagreements: c.Int("top"),
+			})
+
+			switch c.String("output") {
+			case "json":
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(calibrations)
+			case "text":
+				printCalibrations(calibrations)
+				return nil
+			}
+			return fmt.Errorf("unsupported output format %q: expected text or json", c.String("output"))
+		},
+	}
+}
+
+func printCalibrations(calibrations []validator.Calibration) {
+	if len(calibrations) == 0 {
+		fmt.Println("No training entries were scored on the same dimension by more than one evaluator.")
+		return
+	}
+
+	fmt.Println("⚖️  Calibration")
+	fmt.Println("==============")
+	fmt.Println()
+
+	for _, calibration := range calibrations {
+		fmt.Printf("%s: %s vs %s\n", calibration.Dimension, calibration.First, calibration.Second)
+		fmt.Printf("  Paired scores:       %d\n", len(calibration.Pairs))
+		fmt.Printf("  Mean difference:     %+.2f (%s)\n", calibration.MeanDifference, calibrationLeaning(calibration))
+		fmt.Printf("  Mean absolute gap:   %.2f\n", calibration.MeanAbsoluteDifference)
+		fmt.Printf("  Gap over time:       %s %s\n", validator.Sparkline(calibration.Gaps(), 0, maxGap(calibration)), calibrationTrends[calibration.Trend])
+
+		if len(calibration.Disagreements) > 0 {
+			fmt.Println("  Biggest disagreements:")
+			for _, pair := range calibration.Disagreements {
+				date := "undated"
+				if !pair.Date.IsZero() {
+					date = pair.Date.Format(pkg.DateLayout)
+				}
+				fmt.Printf("    %s %s: %s %d, %s %d (%+d)\n", date, pair.ID, calibration.First, pair.First, calibration.Second, pair.Second, pair.Difference)
+				printCalibrationRemarks(calibration.First, pair.FirstRemarks)
+				printCalibrationRemarks(calibration.Second, pair.SecondRemarks)
+			}
+		}
+		fmt.Println()
+	}
+}
+
+// calibrationLeaning describes which evaluator scores higher on average.
+func calibrationLeaning(calibration validator.Calibration) string {
+	switch {
+	case calibration.MeanDifference > 0:
+		return fmt.Sprintf("%s scores higher", calibration.Second)
+	case calibration.MeanDifference < 0:
+		return fmt.Sprintf("%s scores higher", calibration.First)
+	}
+	return "no lean"
+}
+
+func printCalibrationRemarks(evaluator string, remarks string) {
+	if remarks == "" {
+		return
+	}
+	fmt.Printf("      %s: %s\n", evaluator, strings.Join(strings.Fields(remarks), " "))
+}
+
+func maxGap(calibration validator.Calibration) float64 {
+	max := 1.0
+	for _, gap := range calibration.Gaps() {
+		if gap > max {
+			max = gap
+		}
+	}
+	return max
+}
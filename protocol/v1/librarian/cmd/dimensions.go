@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+	"github.com/urfave/cli/v2"
+)
+
+// dimensionUsage is how the evaluation files of a repository declare a dimension.
+type dimensionUsage struct {
+	aliases  []string
+	versions []string
+}
+
+func dimensionsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "dimensions",
+		Usage: "Display the evaluation dimensions with their labels, descriptions, rubrics and versions",
+		Flags: []cli.Flag{
+			directoryFlag("Target directory to scan for evaluation files"),
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "Show the whole dimension catalogue, not only the dimensions declared by evaluation files",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			catalog, err := pkg.LoadDimensionCatalog(plan.Config)
+			if err != nil {
+				return err
+			}
+
+			evaluationFiles, err := validator.GetEvaluationFiles(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get evaluation files: %s", err)
+			}
+
+			usages := map[string]*dimensionUsage{}
+			for _, file := range evaluationFiles {
+				for _, dimension := range file.Definition.Meta.Dimensions {
+					usage, ok := usages[dimension.Name]
+					if !ok {
+						usage = &dimensionUsage{}
+						usages[dimension.Name] = usage
+					}
+					usage.aliases = appendUnique(usage.aliases, dimension.Alias)
+					usage.versions = appendUnique(usage.versions, dimension.Version)
+				}
+			}
+
+			names := []string{}
+			if c.Bool("all") {
+				for _, dimension := range catalog.Dimensions() {
+					names = append(names, dimension.Name)
+				}
+			}
+			for name := range usages {
+				names = appendUnique(names, name)
+			}
+			sort.Strings(names)
+
+			if len(names) == 0 {
+				fmt.Println("No evaluation dimensions found in this repository. Use --all to show the dimension catalogue.")
+				return nil
+			}
+
+			fmt.Println("📏 Evaluation Dimensions")
+			fmt.Println("========================")
+			fmt.Println()
+
+			for _, name := range names {
+				usage := usages[name]
+				dimension, ok := catalog.Lookup(name)
+				if !ok {
+					fmt.Printf("%s\n", name)
+					fmt.Printf("  ⚠️  Not in the dimension catalogue. Add it to %s to describe it.\n", plan.Config.DimensionsDirectory())
+					if usage != nil {
+						fmt.Printf("  Aliases:  %s\n", strings.Join(usage.aliases, ", "))
+					}
+					fmt.Println()
+					continue
+				}
+				printCatalogDimension(dimension, usage)
+			}
+
+			return nil
+		},
+	}
+}
+
+// printCatalogDimension prints a dimension of the catalogue, with the rubric of
+// its latest version, or of the latest version declared by the repository.
+func printCatalogDimension(dimension *pkg.CatalogDimension, usage *dimensionUsage) {
+	source := "official"
+	if !dimension.Official {
+		source = dimension.Source
+	}
+
+	fmt.Printf("%s (%s)\n", dimension.DisplayLabel(), dimension.Name)
+	fmt.Printf("  Source:   %s\n", source)
+	if description := strings.TrimSpace(dimension.Description); description != "" {
+		fmt.Printf("  %s\n", description)
+	}
+	if usage != nil {
+		fmt.Printf("  Aliases:  %s\n", strings.Join(usage.aliases, ", "))
+	}
+
+	rubric := dimension.Latest()
+	fmt.Println("  Versions:")
+	for _, version := range dimension.Versions {
+		marker := ""
+		if usage != nil && contains(usage.versions, version.Version) {
+			marker = " (declared)"
+			rubric = version
+		}
+		line := fmt.Sprintf("    %s", version.Version)
+		if version.Date != "" {
+			line += fmt.Sprintf(" %s", version.Date)
+		}
+		if changes := strings.TrimSpace(version.Changes); changes != "" {
+			line += fmt.Sprintf(" — %s", changes)
+		}
+		fmt.Printf("%s%s\n", line, marker)
+		fmt.Printf("      %s\n", dimension.DefinitionURL(version.Version))
+	}
+
+	if len(rubric.Rubric) > 0 {
+		levels := make([]int, 0, len(rubric.Rubric))
+		for level := range rubric.Rubric {
+			levels = append(levels, level)
+		}
+		sort.Ints(levels)
+
+		fmt.Printf("  Rubric (%s):\n", rubric.Version)
+		for _, level := range levels {
+			fmt.Printf("    %d: %s\n", level, strings.TrimSpace(rubric.Rubric[level]))
+		}
+	}
+	fmt.Println()
+}
+
+func appendUnique(values []string, value string) []string {
+	if value == "" || contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
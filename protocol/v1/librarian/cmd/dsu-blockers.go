@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+	"github.com/urfave/cli/v2"
+)
+
+func dsuBlockersCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "blockers",
+		Usage: "Track blockers across consecutive DSUs and show how long they have been open",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository"),
+			tagFlag(),
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "Also show resolved blockers",
+			},
+			&cli.Float64Flag{
+				Name:  "similarity",
+				Usage: "Minimum text similarity (0 to 1) for blockers on consecutive DSUs to be the same blocker",
+				Value: validator.DefaultBlockerSimilarity,
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Output format: text or json",
+				Value: "text",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			now, err := today(plan)
+			if err != nil {
+				return err
+			}
+
+			entries, err := validator.GetAllDSUEntries(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get DSU entries: %s", err)
+			}
+
+			entries = validator.FilterDSUsByTags(entries, c.StringSlice("tag"))
+
+			blockers := []validator.Blocker{}
+			for _, blocker := range validator.TrackBlockers(entries, c.Float64("similarity")) {
+				if blocker.Open || c.Bool("all") {
+					blockers = append(blockers, blocker)
+				}
+			}
+
+			switch c.String("output") {
+			case "json":
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(blockers)
+			case "text":
+			default:
+				return fmt.Errorf("unsupported output format %q: expected text or json", c.String("output"))
+			}
+
+			if len(blockers) == 0 && c.Bool("all") {
+				fmt.Println("✅ No blockers reported!")
+				return nil
+			}
+			if len(blockers) == 0 {
+				fmt.Println("✅ No open blockers!")
+				return nil
+			}
+
+			for _, blocker := range blockers {
+				if blocker.Open {
+					fmt.Printf("🚧 %s\n", blocker.Text)
+					fmt.Printf("   Open for %d days, since %s (%d consecutive DSUs)\n", blocker.Days(now), blocker.FirstSeen.Format(pkg.DateLayout), blocker.Occurrences)
+				} else {
+					fmt.Printf("✅ %s\n", blocker.Text)
+					fmt.Printf("   Lasted %d days, from %s to %s (%d consecutive DSUs)\n", blocker.Days(blocker.LastSeen)+1, blocker.FirstSeen.Format(pkg.DateLayout), blocker.LastSeen.Format(pkg.DateLayout), blocker.Occurrences)
+				}
+				fmt.Printf("   First reported in %s\n\n", blocker.FirstSeenID)
+			}
+
+			return nil
+		},
+	}
+}
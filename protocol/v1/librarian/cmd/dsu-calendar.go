@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+	"github.com/urfave/cli/v2"
+)
+
+func dsuStreakCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "streak",
+		Usage: "Show the current and longest streak of working days with a DSU",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository"),
+			tagFlag(),
+		},
+		Action: func(c *cli.Context) error {
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			calendar, err := pkg.LoadCalendar(plan.Config)
+			if err != nil {
+				return fmt.Errorf("failed to load calendar: %s", err)
+			}
+
+			now, err := today(plan)
+			if err != nil {
+				return err
+			}
+
+			entries, err := validator.GetAllDSUEntries(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get DSU entries: %s", err)
+			}
+			entries = validator.FilterDSUsByTags(entries, c.StringSlice("tag"))
+
+			if len(entries) == 0 {
+				fmt.Println("No DSU entries found.")
+				return nil
+			}
+
+			report := validator.ComputeStreaks(entries, calendar, now)
+
+			if report.Current > 0 {
+				fmt.Printf("🔥 Current streak: %d working days (since %s)\n", report.Current, report.CurrentStart.Format(pkg.DateLayout))
+			} else {
+				fmt.Println("🔥 Current streak: 0 working days")
+			}
+			if report.Longest > 0 {
+				fmt.Printf("🏆 Longest streak: %d working days (%s → %s)\n", report.Longest, report.LongestStart.Format(pkg.DateLayout), report.LongestEnd.Format(pkg.DateLayout))
+			} else {
+				fmt.Println("🏆 Longest streak: 0 working days")
+			}
+			fmt.Printf("📅 Last DSU: %s\n", report.LastEntry.Format(pkg.DateLayout))
+
+			return nil
+		},
+	}
+}
+
+func dsuGapsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "gaps",
+		Usage: "List working days without a DSU",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository"),
+			tagFlag(),
+			&cli.StringFlag{
+				Name:        "since",
+				Usage:       "First day to check (YYYY-MM-DD)",
+				DefaultText: "date of the first DSU",
+			},
+			&cli.StringFlag{
+				Name:        "until",
+				Usage:       "Last day to check (YYYY-MM-DD)",
+				DefaultText: "yesterday",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			calendar, err := pkg.LoadCalendar(plan.Config)
+			if err != nil {
+				return fmt.Errorf("failed to load calendar: %s", err)
+			}
+
+			entries, err := validator.GetAllDSUEntries(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get DSU entries: %s", err)
+			}
+			entries = validator.FilterDSUsByTags(entries, c.StringSlice("tag"))
+
+			since, err := parseDateFlag(c, "since")
+			if err != nil {
+				return err
+			}
+			if since.IsZero() {
+				since = validator.FirstDSUDate(entries)
+			}
+			if since.IsZero() {
+				fmt.Println("No DSU entries found.")
+				return nil
+			}
+
+			until, err := parseDateFlag(c, "until")
+			if err != nil {
+				return err
+			}
+			if until.IsZero() {
+				now, err := today(plan)
+				if err != nil {
+					return err
+				}
+				until = now.AddDate(0, 0, -1)
+			}
+
+			gaps := validator.FindGaps(entries, calendar, since, until)
+			if len(gaps) == 0 {
+				fmt.Printf("✅ No missing DSUs between %s and %s!\n", since.Format(pkg.DateLayout), until.Format(pkg.DateLayout))
+				return nil
+			}
+
+			fmt.Printf("Found %d working days without a DSU between %s and %s:\n\n", len(gaps), since.Format(pkg.DateLayout), until.Format(pkg.DateLayout))
+			for _, gap := range gaps {
+				fmt.Printf("%s (%s)\n", gap.Format(pkg.DateLayout), gap.Weekday())
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+	"github.com/urfave/cli/v2"
+)
+
+func dsuContinuityCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "continuity",
+		Usage: "Compare each DSU's doing_today with the next DSU's done_yesterday to find dropped and unplanned tasks",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository"),
+			tagFlag(),
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Only check entries on or after this date (YYYY-MM-DD)",
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: "Only check entries on or before this date (YYYY-MM-DD)",
+			},
+			&cli.Float64Flag{
+				Name:  "similarity",
+				Usage: "Minimum text similarity (0 to 1) for a planned and a reported item to be the same task",
+				Value: validator.DefaultContinuitySimilarity,
+			},
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "Also show consecutive DSUs where every planned task was reported done",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Output format: text or json",
+				Value: "text",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			since, err := parseDateFlag(c, "since")
+			if err != nil {
+				return err
+			}
+			until, err := parseDateFlag(c, "until")
+			if err != nil {
+				return err
+			}
+
+			entries, err := validator.GetAllDSUEntries(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get DSU entries: %s", err)
+			}
+
+			filtered := []pkg.DSUReport{}
+			for _, entry := range validator.FilterDSUsByTags(entries, c.StringSlice("tag")) {
+				if validator.InDateRange(entry.Datetime, since, until) {
+					filtered = append(filtered, entry)
+				}
+			}
+
+			report := validator.CheckContinuity(filtered, c.Float64("similarity"))
+
+			switch c.String("output") {
+			case "json":
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(report)
+			case "text":
+			default:
+				return fmt.Errorf("unsupported output format %q: expected text or json", c.String("output"))
+			}
+
+			if len(report.Transitions) == 0 {
+				fmt.Println("Not enough DSU entries to compare.")
+				return nil
+			}
+
+			for _, transition := range report.Transitions {
+				if len(transition.Dropped) == 0 && len(transition.Unplanned) == 0 && !c.Bool("all") {
+					continue
+				}
+
+				fmt.Printf("%s → %s (%s → %s)\n", transition.FromDate.Format(pkg.DateLayout), transition.ToDate.Format(pkg.DateLayout), transition.FromID, transition.ToID)
+				for _, item := range transition.Dropped {
+					fmt.Printf("  ❌ planned, never reported done: %s\n", item)
+				}
+				for _, item := range transition.Unplanned {
+					fmt.Printf("  ➕ done, never planned: %s\n", item)
+				}
+				if len(transition.Dropped) == 0 && len(transition.Unplanned) == 0 {
+					fmt.Println("  ✅ everything planned was reported done")
+				}
+				fmt.Println()
+			}
+
+			fmt.Printf("📐 Planning accuracy: %d of %d planned tasks reported done (%.0f%%)\n", report.Completed, report.Planned, report.Accuracy()*100)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+	"github.com/urfave/cli/v2"
+)
+
+func dsuImportCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "Import DSUs from a CSV file, Markdown daily notes or a Slack export",
+		ArgsUsage: "<path>",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository"),
+			&cli.StringFlag{
+				Name:     "from",
+				Usage:    "Format of the imported file or directory: csv, markdown or slack-json",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "tag",
+				Usage: "Tag to add to every imported entry (repeatable)",
+			},
+			&cli.StringFlag{
+				Name:  "user",
+				Usage: "Only import the Slack messages of this user ID or name",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Print the entries that would be imported without writing them",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 1 {
+				return fmt.Errorf("expected the path to import, e.g. tome dsu import --from csv dsus.csv")
+			}
+
+			source, err := validator.ParseImportSource(c.String("from"))
+			if err != nil {
+				return err
+			}
+
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			loc, err := plan.Config.Location()
+			if err != nil {
+				return err
+			}
+
+			parser, err := plan.Config.DatetimeParser()
+			if err != nil {
+				return err
+			}
+
+			entries, rejected, err := validator.ImportDSUs(source, c.Args().First(), validator.ImportOptions{
+				Location:  loc,
+				Datetime:  parser,
+				SlackUser: c.String("user"),
+			})
+			if err != nil {
+				return err
+			}
+
+			existing, err := validator.GetAllDSUEntries(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get DSU entries: %s", err)
+			}
+			known := map[string]bool{}
+			for _, entry := range existing {
+				known[entry.ID] = true
+			}
+
+			pending := []pkg.DSUReport{}
+			skipped := 0
+			for _, imported := range entries {
+				entry := imported.DSUReport
+				if known[entry.ID] {
+					skipped++
+					continue
+				}
+
+				entry.Tags = append(entry.Tags, c.StringSlice("tag")...)
+
+				if err := validator.ValidateDSUTags(plan.Config, entry); err != nil {
+					rejected = append(rejected, validator.RejectedDSU{Source: imported.Source, Err: err})
+					continue
+				}
+
+				target, source, err := dsuTargetPath(plan, entry.Datetime.In(loc))
+				if err != nil {
+					return err
+				}
+				if _, err := os.Stat(target); err != nil && source != "" {
+					target = source
+				}
+				if err := validateDSUFields(plan, target, entry); err != nil {
+					rejected = append(rejected, validator.RejectedDSU{Source: imported.Source, Err: err})
+					continue
+				}
+				known[entry.ID] = true
+				pending = append(pending, entry)
+			}
+
+			for _, reject := range rejected {
+				fmt.Printf("⚠️  Skipped the DSU of %s\n", reject)
+			}
+
+			// Invalid entries are reported and skipped before writing the others.
+			for _, entry := range pending {
+				if c.Bool("dry-run") {
+					target, _, err := dsuTargetPath(plan, entry.Datetime.In(loc))
+					if err != nil {
+						return err
+					}
+					fmt.Printf("Would add DSU entry %s (%s) to %s\n", entry.ID, entry.DatetimeRaw, target)
+					continue
+				}
+
+				target, err := dsuTargetFile(plan, entry.Datetime.In(loc))
+				if err != nil {
+					return err
+				}
+				if err := validator.AppendDSUEntry(target, entry); err != nil {
+					return fmt.Errorf("failed to write DSU entry: %s", err)
+				}
+				fmt.Printf("📝 Added DSU entry %s (%s) to %s\n", entry.ID, entry.DatetimeRaw, target)
+			}
+
+			fmt.Printf("\nImported %d DSU entries, skipped %d already imported and %d invalid.\n", len(pending), skipped, len(rejected))
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v2"
+)
+
+func dsuListCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls"},
+		Usage:   "List DSU entries with their blockers and evaluation status",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository"),
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Only list entries on or after this date (YYYY-MM-DD)",
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: "Only list entries on or before this date (YYYY-MM-DD)",
+			},
+			tagFlag(),
+			&cli.IntFlag{
+				Name:  "last",
+				Usage: "Only list the N most recent entries",
+			},
+			&cli.BoolFlag{
+				Name:  "reverse",
+				Usage: "List the most recent entries first",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Output format: table, json, yaml or csv",
+				Value:   "table",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			since, err := parseDateFlag(c, "since")
+			if err != nil {
+				return err
+			}
+			until, err := parseDateFlag(c, "until")
+			if err != nil {
+				return err
+			}
+
+			listings, err := validator.ListDSUs(plan, since, until, c.StringSlice("tag"))
+			if err != nil {
+				return fmt.Errorf("failed to list DSU entries: %s", err)
+			}
+
+			if last := c.Int("last"); last > 0 && len(listings) > last {
+				listings = listings[len(listings)-last:]
+			}
+
+			if c.Bool("reverse") {
+				for i, j := 0, len(listings)-1; i < j; i, j = i+1, j-1 {
+					listings[i], listings[j] = listings[j], listings[i]
+				}
+			}
+
+			return writeDSUListings(c.String("output"), listings)
+		},
+	}
+}
+
+func writeDSUListings(format string, listings []validator.DSUListing) error {
+	fields := listingFieldNames(listings)
+
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(listings)
+
+	case "yaml":
+		out, err := yaml.Marshal(listings)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(out)
+		return err
+
+	case "csv":
+		writer := csv.NewWriter(os.Stdout)
+		writer.Write(append([]string{"id", "datetime", "tags", "has_blockers", "evaluated", "file", "evaluated_by"}, fields...))
+		for _, listing := range listings {
+			record := []string{
+				listing.ID,
+				listing.Datetime.Format(time.RFC3339),
+				strings.Join(listing.Tags, ";"),
+				strconv.FormatBool(listing.HasBlockers),
+				strconv.FormatBool(listing.Evaluated),
+				listing.File,
+				strings.Join(listing.EvaluatedBy, ";"),
+			}
+			for _, field := range fields {
+				record = append(record, pkg.FormatFieldValue(listing.Fields[field]))
+			}
+			writer.Write(record)
+		}
+		writer.Flush()
+		return writer.Error()
+
+	case "table":
+		if len(listings) == 0 {
+			fmt.Println("No DSU entries found.")
+			return nil
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		header := "ID\tDATE\tBLOCKERS\tEVALUATED BY\tTAGS"
+		for _, field := range fields {
+			header += "\t" + strings.ToUpper(field)
+		}
+		fmt.Fprintln(writer, header)
+		for _, listing := range listings {
+			blockers := "-"
+			if listing.HasBlockers {
+				blockers = "yes"
+			}
+			evaluated := "-"
+			if listing.Evaluated {
+				evaluated = strings.Join(listing.EvaluatedBy, ", ")
+			}
+			row := fmt.Sprintf("%s\t%s\t%s\t%s\t%s", listing.ID, listing.Datetime.Format("2006-01-02 15:04:05"), blockers, evaluated, strings.Join(listing.Tags, ", "))
+			for _, field := range fields {
+				value := pkg.FormatFieldValue(listing.Fields[field])
+				if value == "" {
+					value = "-"
+				}
+				row += "\t" + value
+			}
+			fmt.Fprintln(writer, row)
+		}
+		return writer.Flush()
+	}
+
+	return fmt.Errorf("unsupported output format %q: expected table, json, yaml or csv", format)
+}
+
+// listingFieldNames returns the names of the custom fields of the listings, sorted.
+func listingFieldNames(listings []validator.DSUListing) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, listing := range listings {
+		for name := range listing.Fields {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,505 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v2"
+)
+
+const dsuEditorHeader = `# Edit the DSU entry below, then save and close the editor.
+# Lines starting with '#' are ignored. done_yesterday and doing_today are required.
+`
+
+// dsuCommand groups the commands for working with DSU entries.
+func dsuCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "dsu",
+		Usage: "Create and manage daily stand-up (DSU) entries",
+		Subcommands: []*cli.Command{
+			dsuNewCommand(),
+			dsuEditCommand(),
+			dsuRemoveCommand(),
+			dsuListCommand(),
+			dsuRotateCommand(),
+			dsuStreakCommand(),
+			dsuGapsCommand(),
+			dsuBlockersCommand(),
+			dsuImportCommand(),
+			dsuContinuityCommand(),
+		},
+	}
+}
+
+func dsuNewCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "new",
+		Usage: "Scaffold today's DSU entry, carrying over yesterday's plan, and open it in $EDITOR",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository"),
+			&cli.StringFlag{
+				Name:  "doing-today",
+				Usage: "Prefill doing_today",
+			},
+			&cli.StringFlag{
+				Name:  "blockers",
+				Usage: "Prefill blockers",
+			},
+			&cli.StringFlag{
+				Name:  "remarks",
+				Usage: "Prefill remarks",
+			},
+			&cli.StringSliceFlag{
+				Name:  "tag",
+				Usage: "Tag the entry, in addition to its file's meta.tags (repeatable)",
+			},
+			&cli.BoolFlag{
+				Name:  "no-edit",
+				Usage: "Write the entry without opening $EDITOR",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			loc, err := plan.Config.Location()
+			if err != nil {
+				return err
+			}
+
+			parser, err := plan.Config.DatetimeParser()
+			if err != nil {
+				return err
+			}
+
+			id, err := pkg.NewUUID()
+			if err != nil {
+				return fmt.Errorf("failed to generate UUID: %s", err)
+			}
+
+			now := time.Now().In(loc)
+			entry := pkg.DSUReport{
+				ID:          id,
+				DatetimeRaw: now.Format(plan.Config.Datetime.StampLayout()),
+				DoingToday:  c.String("doing-today"),
+				Blockers:    c.String("blockers"),
+				Remarks:     c.String("remarks"),
+				Tags:        c.StringSlice("tag"),
+			}
+
+			if latest, err := validator.GetLatestDSU(plan); err == nil {
+				entry.DoneYesterday = latest.DoingToday
+			}
+
+			if !c.Bool("no-edit") {
+				entry, err = editDSUEntry(entry)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := validator.ValidateDSUEntry(entry); err != nil {
+				return err
+			}
+
+			if err := entry.ParseDatetime(parser); err != nil {
+				return err
+			}
+
+			if err := validator.ValidateDSUTags(plan.Config, entry); err != nil {
+				return err
+			}
+
+			target, err := dsuTargetFile(plan, now)
+			if err != nil {
+				return err
+			}
+
+			if err := validateDSUFields(plan, target, entry); err != nil {
+				return err
+			}
+
+			if err := validator.AppendDSUEntry(target, entry); err != nil {
+				return fmt.Errorf("failed to write DSU entry: %s", err)
+			}
+
+			fmt.Printf("📝 Added DSU entry %s to %s\n", entry.ID, target)
+
+			return nil
+		},
+	}
+}
+
+func dsuEditCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "edit",
+		Usage: "Open a DSU entry in $EDITOR and write it back in place",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository"),
+			&cli.StringFlag{
+				Name:     "uuid",
+				Aliases:  []string{"u"},
+				Usage:    "UUID of the DSU entry to edit",
+				Required: true,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			uuid := c.String("uuid")
+
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			file, entry, err := validator.FindDSUEntry(plan, uuid)
+			if err != nil {
+				return fmt.Errorf("failed to get DSU entry: %s", err)
+			}
+
+			edited, err := editDSUEntry(*entry)
+			if err != nil {
+				return err
+			}
+
+			if edited.ID != entry.ID {
+				return fmt.Errorf("the id of DSU entry %s cannot be changed", entry.ID)
+			}
+
+			if err := validator.ValidateDSUEntry(edited); err != nil {
+				return err
+			}
+
+			parser, err := plan.Config.DatetimeParser()
+			if err != nil {
+				return err
+			}
+			if err := edited.ParseDatetime(parser); err != nil {
+				return err
+			}
+
+			if err := validator.ValidateDSUTags(plan.Config, edited); err != nil {
+				return err
+			}
+
+			if err := validateDSUFields(plan, file.Filepath, edited); err != nil {
+				return err
+			}
+
+			if err := validator.ReplaceDSUEntry(file.Filepath, entry.ID, edited); err != nil {
+				return fmt.Errorf("failed to write DSU entry: %s", err)
+			}
+
+			fmt.Printf("📝 Updated DSU entry %s in %s\n", entry.ID, file.Filepath)
+
+			return nil
+		},
+	}
+}
+
+func dsuRemoveCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "remove",
+		Aliases: []string{"rm"},
+		Usage:   "Remove a DSU entry by its UUID",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository"),
+			&cli.StringFlag{
+				Name:     "uuid",
+				Aliases:  []string{"u"},
+				Usage:    "UUID of the DSU entry to remove",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Remove the entry even if evaluations reference it",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			uuid := c.String("uuid")
+
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			file, entry, err := validator.FindDSUEntry(plan, uuid)
+			if err != nil {
+				return fmt.Errorf("failed to get DSU entry: %s", err)
+			}
+
+			references, err := validator.FindEvaluationReferences(plan, entry.ID)
+			if err != nil {
+				return fmt.Errorf("failed to find evaluations: %s", err)
+			}
+
+			if len(references) > 0 {
+				fmt.Printf("⚠️  DSU entry %s is referenced by evaluations in:\n", entry.ID)
+				for _, reference := range references {
+					fmt.Printf("  - %s\n", reference)
+				}
+				if !c.Bool("force") {
+					return fmt.Errorf("refusing to remove an evaluated DSU entry; use --force to remove it anyway")
+				}
+			}
+
+			if err := validator.RemoveDSUEntry(file.Filepath, entry.ID); err != nil {
+				return fmt.Errorf("failed to remove DSU entry: %s", err)
+			}
+
+			fmt.Printf("🗑️  Removed DSU entry %s from %s\n", entry.ID, file.Filepath)
+
+			return nil
+		},
+	}
+}
+
+func dsuRotateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "rotate",
+		Usage: "Move DSU entries out of oversized training files into one file per period",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository"),
+			&cli.StringFlag{
+				Name:        "by",
+				Usage:       "Rotation period: quarter, month or year",
+				DefaultText: "dsu.rotation in tome.yaml",
+			},
+			&cli.IntFlag{
+				Name:  "max-entries",
+				Usage: "Only rotate training files with more than this many entries (0 rotates every file)",
+				Value: validator.DefaultRotationThreshold,
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show which entries would be moved without writing any file",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			by := c.String("by")
+			if by == "" {
+				by = plan.Config.DSU.Rotation
+			}
+			if by == "" {
+				return fmt.Errorf("no rotation period specified; use --by or set dsu.rotation in tome.yaml")
+			}
+
+			period, err := validator.ParseRotationPeriod(by)
+			if err != nil {
+				return err
+			}
+
+			moved := 0
+			rotated := map[string]bool{}
+			for {
+				// The files are read again after each rotation, which may have
+				// created period files or added entries to existing ones.
+				file, err := nextDSUFileToRotate(plan, rotated, c.Int("max-entries"))
+				if err != nil {
+					return err
+				}
+				if file == nil {
+					break
+				}
+				rotated[file.Filepath] = true
+
+				moves, err := validator.RotateDSUFile(*file, period, c.Bool("dry-run"))
+				if err != nil {
+					return fmt.Errorf("failed to rotate %s: %s", file.Filepath, err)
+				}
+
+				for _, move := range moves {
+					fmt.Printf("%s: %s -> %s\n", move.ID, move.From, move.To)
+				}
+				moved += len(moves)
+
+				if len(moves) > 0 && !c.Bool("dry-run") {
+					if plan, err = loadPlan(c.String("directory")); err != nil {
+						return err
+					}
+				}
+			}
+
+			if moved == 0 {
+				fmt.Println("✅ All DSU entries are already in their period files.")
+				return nil
+			}
+
+			if c.Bool("dry-run") {
+				fmt.Printf("\n%d DSU entries would be moved (dry run).\n", moved)
+			} else {
+				fmt.Printf("\n🗂️  Moved %d DSU entries into %s files.\n", moved, period)
+			}
+
+			return nil
+		},
+	}
+}
+
+// nextDSUFileToRotate returns the first DSU file not rotated yet with more
+// than maxEntries entries, nil when there is none.
+func nextDSUFileToRotate(plan *pkg.ValidationPlan, rotated map[string]bool, maxEntries int) (*validator.DSUFile, error) {
+	dsuFiles, err := validator.GetDSUFiles(plan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DSU files: %s", err)
+	}
+
+	for i, file := range dsuFiles {
+		if !rotated[file.Filepath] && len(file.Definition.Content) > maxEntries {
+			return &dsuFiles[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// dsuTargetFile returns the training file where new entries dated now are
+// appended. When DSU files are rotated, either through dsu.rotation in
+// tome.yaml or by following the naming of the latest file, the file for the
+// current period is used and created when missing.
+func dsuTargetFile(plan *pkg.ValidationPlan, now time.Time) (string, error) {
+	target, source, err := dsuTargetPath(plan, now)
+	if err != nil {
+		return "", err
+	}
+	return createDSUFileIfMissing(target, source)
+}
+
+// dsuTargetPath returns the training file a DSU entry dated now belongs to,
+// along with the file whose header it is created from when missing.
+func dsuTargetPath(plan *pkg.ValidationPlan, now time.Time) (string, string, error) {
+	latest, latestErr := validator.GetLatestDSUFile(plan)
+
+	var period validator.RotationPeriod
+	base := "dsu-reports"
+	directory := plan.Config.TrainingDirectory()
+
+	if latest != nil {
+		detected, detectedBase, ok := validator.DetectRotationPeriod(latest.Filepath)
+		if ok {
+			period = detected
+		}
+		base = detectedBase
+		directory = filepath.Dir(latest.Filepath)
+	}
+
+	if plan.Config.DSU.Rotation != "" {
+		configured, err := validator.ParseRotationPeriod(plan.Config.DSU.Rotation)
+		if err != nil {
+			return "", "", err
+		}
+		period = configured
+	}
+
+	if period == "" {
+		if latestErr == nil {
+			return latest.Filepath, "", nil
+		}
+		return filepath.Join(directory, base+".yaml"), "", nil
+	}
+
+	source := ""
+	if latest != nil {
+		source = latest.Filepath
+	}
+
+	return filepath.Join(directory, period.Filename(base, now)), source, nil
+}
+
+// validateDSUFields checks the custom fields of the entry against those
+// declared by the training file at path, warning about undeclared ones.
+func validateDSUFields(plan *pkg.ValidationPlan, path string, entry pkg.DSUReport) error {
+	fields, err := validator.DSUFileFields(plan.Config, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	undeclared, err := validator.ValidateDSUFields(fields, entry)
+	if err != nil {
+		return err
+	}
+	for _, name := range undeclared {
+		fmt.Printf("⚠️  Field %s is not declared in the meta.format.fields of %s\n", name, path)
+	}
+
+	return nil
+}
+
+// createDSUFileIfMissing creates the training file, copying the header of the
+// source file when one is given.
+func createDSUFileIfMissing(target string, source string) (string, error) {
+	if _, err := os.Stat(target); err == nil {
+		return target, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	var err error
+	if source != "" {
+		err = validator.CreateDSUFileFrom(target, source)
+	} else {
+		err = validator.CreateDSUFile(target, nil)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s: %s", target, err)
+	}
+
+	return target, nil
+}
+
+// editDSUEntry opens the entry in $EDITOR as YAML and returns the edited entry.
+// When the edited entry is not valid YAML or misses a required field, the
+// edits are kept in the temporary file, whose path is part of the error.
+func editDSUEntry(entry pkg.DSUReport) (pkg.DSUReport, error) {
+	file, err := os.CreateTemp("", "tome-dsu-*.yaml")
+	if err != nil {
+		return entry, err
+	}
+	keep := false
+	defer func() {
+		if !keep {
+			os.Remove(file.Name())
+		}
+	}()
+
+	_, err = file.WriteString(dsuEditorHeader + validator.RenderDSUEntry(entry))
+	file.Close()
+	if err != nil {
+		return entry, err
+	}
+
+	if err := openEditor(file.Name()); err != nil {
+		return entry, err
+	}
+
+	fileBytes, err := os.ReadFile(file.Name())
+	if err != nil {
+		return entry, err
+	}
+
+	var edited pkg.UnparsedDSUReport
+	if err := yaml.Unmarshal(fileBytes, &edited); err != nil {
+		keep = true
+		return entry, fmt.Errorf("failed to parse edited DSU entry: %s\nYour edits are kept in %s", err, file.Name())
+	}
+	if err := validator.ValidateDSUEntry(pkg.DSUReport(edited)); err != nil {
+		keep = true
+		return entry, fmt.Errorf("%s\nYour edits are kept in %s", err, file.Name())
+	}
+
+	return pkg.DSUReport(edited), nil
+}
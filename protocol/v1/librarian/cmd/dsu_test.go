@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+// scriptedEditor sets $EDITOR to a script overwriting the edited file with content.
+func scriptedEditor(t *testing.T, content string) {
+	script := filepath.Join(t.TempDir(), "editor.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\ncat > \"$1\" <<'EOF'\n"+content+"EOF\n"), 0755); err != nil {
+		t.Fatalf("failed to write the editor script: %s", err)
+	}
+	t.Setenv("EDITOR", script)
+}
+
+func TestEditDSUEntryKeepsInvalidEdits(t *testing.T) {
+	entry := pkg.DSUReport{ID: "e1", DatetimeRaw: "2024-07-01", DoneYesterday: "- Task A\n", DoingToday: "- Task B\n"}
+
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{"invalid YAML", "id: e1\ndoing_today: [unclosed\n"},
+		{"missing done_yesterday", "id: e1\ndatetime: 2024-07-01\ndoing_today: |\n  - Task B\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scriptedEditor(t, tc.content)
+
+			_, err := editDSUEntry(entry)
+			if err == nil {
+				t.Fatal("Expected the edited entry to be rejected")
+			}
+			_, kept, found := strings.Cut(err.Error(), "Your edits are kept in ")
+			if !found {
+				t.Fatalf("Expected the error to name the file keeping the edits, but got %s", err)
+			}
+			defer os.Remove(kept)
+
+			keptBytes, err := os.ReadFile(kept)
+			if err != nil || string(keptBytes) != tc.content {
+				t.Errorf("Expected the edits to be kept in %s, but got %q, %v", kept, keptBytes, err)
+			}
+		})
+	}
+
+	t.Run("valid entry", func(t *testing.T) {
+		scriptedEditor(t, "id: e1\ndatetime: 2024-07-01\ndone_yesterday: |\n  - Task A\ndoing_today: |\n  - Task C\n")
+
+		edited, err := editDSUEntry(entry)
+		if err != nil {
+			t.Fatalf("editDSUEntry failed: %s", err)
+		}
+		if edited.DoingToday != "- Task C\n" {
+			t.Errorf("Expected the edited doing_today, but got %q", edited.DoingToday)
+		}
+	})
+}
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+	"github.com/urfave/cli/v2"
+)
+
+// errQuitEvaluation stops the evaluation session, keeping the evaluations saved so far.
+var errQuitEvaluation = errors.New("evaluation stopped")
+
+func evaluateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "evaluate",
+		Usage: "Evaluate the DSU entries missing an evaluation, one at a time",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository"),
+			&cli.StringFlag{
+				Name:        "file",
+				Usage:       "Evaluation file to append the evaluations to",
+				DefaultText: "evaluations/self.yaml",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			path := c.String("file")
+			if path == "" {
+				path = filepath.Join(plan.Config.EvaluationsDirectory(), "self.yaml")
+			}
+
+			file, err := validator.ReadEvaluationFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read evaluation file: %s", err)
+			}
+			if file.IsMeta() {
+				return fmt.Errorf("%s holds meta evaluations of the mentor, choose an evaluation file of the training with --file", path)
+			}
+			if len(file.Definition.Meta.Dimensions) == 0 {
+				return fmt.Errorf("%s declares no dimensions in meta.dimensions", path)
+			}
+
+			catalog, err := pkg.LoadDimensionCatalog(plan.Config)
+			if err != nil {
+				logrus.Warnf("failed to load the dimension catalogue, rubrics will not be shown: %s", err)
+			}
+
+			missing, err := validator.FindMissingEvaluationsBy(plan, file.EvaluatorName(), nil, false)
+			if err != nil {
+				return fmt.Errorf("failed to find missing evaluations: %s", err)
+			}
+
+			if len(missing) == 0 {
+				fmt.Printf("✅ All DSU entries have evaluations by %s!\n", file.EvaluatorName())
+				return nil
+			}
+
+			// Appending to a signed file breaks its whole-file signature, and
+			// leaves the new records unsigned, which validate rejects once the
+			// evaluator's key is pinned in tome.yaml.
+			_, pinned := validator.TrustedKeys(plan.Config, file, nil)
+			if file.SignedAsWhole() {
+				fmt.Printf("⚠️  %s is signed as a whole: saving evaluations invalidates its signature until you sign it again.\n", path)
+			}
+
+			fmt.Printf("Found %d DSU entries without evaluations by %s.\n", len(missing), file.EvaluatorName())
+			fmt.Println("Leave a score empty to skip the dimension, enter s to skip the entry or q to quit.")
+
+			reader := bufio.NewReader(os.Stdin)
+			saved := []string{}
+			for i, entry := range missing {
+				fmt.Printf("\n📝 DSU %d of %d\n\n", i+1, len(missing))
+				printDSUEntry(entry)
+				fmt.Println()
+
+				record, err := promptEvaluationRecord(reader, file, catalog, entry.ID)
+				if errors.Is(err, errQuitEvaluation) {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				if len(record.Measurements) == 0 {
+					fmt.Println("⏭️  Skipped")
+					continue
+				}
+
+				if err := validator.ValidateMeasurements(file, record, catalog); err != nil {
+					return fmt.Errorf("invalid evaluation of %s: %s", entry.ID, err)
+				}
+				if err := validator.AppendEvaluationRecord(path, record); err != nil {
+					return fmt.Errorf("failed to save evaluation of %s: %s", entry.ID, err)
+				}
+				saved = append(saved, entry.ID)
+				fmt.Printf("✅ Saved evaluation of %s to %s\n", entry.ID, path)
+			}
+
+			fmt.Printf("\n📚 Saved %d evaluations, %d DSU entries left to evaluate.\n", len(saved), len(missing)-len(saved))
+
+			switch {
+			case len(saved) == 0:
+			case file.SignedAsWhole():
+				fmt.Printf("✍️  Sign %s again with: tome sign %s\n", path, path)
+			case pinned || len(file.Definition.Meta.Signatures) > 0:
+				fmt.Printf("✍️  Sign the new evaluations with: tome sign --record %s %s\n", strings.Join(saved, " --record "), path)
+			}
+			return nil
+		},
+	}
+}
+
+// promptEvaluationRecord asks for a measurement, remarks, wins and mistakes for
+// each dimension declared by the evaluation file. The record has no
+// measurements when the entry is skipped.
+func promptEvaluationRecord(reader *bufio.Reader, file validator.EvaluationFile, catalog *pkg.DimensionCatalog, id string) (pkg.EvaluationRecord[pkg.Measurement], error) {
+	record := pkg.EvaluationRecord[pkg.Measurement]{ID: id}
+
+	for _, dimension := range file.Definition.Meta.Dimensions {
+		measurement, skip, err := promptMeasurement(reader, dimension, catalog)
+		if err != nil {
+			return record, err
+		}
+		if skip {
+			return pkg.EvaluationRecord[pkg.Measurement]{ID: id}, nil
+		}
+		if measurement == nil {
+			continue
+		}
+
+		for _, field := range []struct {
+			label string
+			value *string
+		}{
+			{"Remarks", &measurement.Remarks},
+			{"Wins", &measurement.Wins},
+			{"Mistakes", &measurement.Mistakes},
+		} {
+			answer, err := prompt(reader, fmt.Sprintf("  %s: ", field.label))
+			if err != nil {
+				return record, err
+			}
+			*field.value = answer
+		}
+
+		record.Measurements = append(record.Measurements, *measurement)
+	}
+
+	return record, nil
+}
+
+// promptMeasurement asks for the value of a measurement of the dimension, by
+// the kind of measurement it declares. The measurement is nil when the
+// dimension is skipped.
+func promptMeasurement(reader *bufio.Reader, dimension pkg.DimensionDeclaration, catalog *pkg.DimensionCatalog) (*pkg.Measurement, bool, error) {
+	measurement := &pkg.Measurement{Dimension: dimension.Alias}
+
+	switch dimension.MeasurementKind() {
+	case pkg.MeasurementRubric:
+		levels := validator.RubricLevels(dimension, catalog)
+		printDimensionLevels(dimension, levels)
+		level, skip, err := promptNumber(reader, fmt.Sprintf("%s level: ", dimension.Alias), func(level int) error {
+			if _, ok := levels[level]; len(levels) > 0 && !ok {
+				return fmt.Errorf("%d is not a level of the rubric", level)
+			}
+			return nil
+		})
+		if level == nil || skip || err != nil {
+			return nil, skip, err
+		}
+		measurement.Level = level
+
+	case pkg.MeasurementChecklist:
+		fmt.Printf("📏 %s\n", dimension.Name)
+		checklist := map[string]bool{}
+		for _, criterion := range dimension.Criteria {
+			met, skip, err := promptYesNo(reader, fmt.Sprintf("  %s met? (y/n): ", criterion))
+			if met == nil || skip || err != nil {
+				return nil, skip, err
+			}
+			checklist[criterion] = *met
+		}
+		measurement.Checklist.Checklist = checklist
+
+	case pkg.MeasurementPassFail:
+		fmt.Printf("📏 %s\n", dimension.Name)
+		passed, skip, err := promptYesNo(reader, fmt.Sprintf("%s passed? (y/n): ", dimension.Alias))
+		if passed == nil || skip || err != nil {
+			return nil, skip, err
+		}
+		measurement.Passed = passed
+
+	default:
+		printDimensionLevels(dimension, validator.RubricLevels(dimension, catalog))
+		hint := ""
+		scale := validator.ScoreScale(dimension, catalog)
+		if scale != nil {
+			hint = fmt.Sprintf(" (%s)", scale.FormatRange())
+		}
+		score, skip, err := promptNumber(reader, fmt.Sprintf("%s score%s: ", dimension.Alias, hint), func(score int) error {
+			if scale == nil {
+				return nil
+			}
+			return validator.CheckScore(*scale, score)
+		})
+		if score == nil || skip || err != nil {
+			return nil, skip, err
+		}
+		measurement.Score = score
+	}
+
+	return measurement, false, nil
+}
+
+// promptNumber asks for a whole number until check accepts it. The number is
+// nil when the dimension is skipped.
+func promptNumber(reader *bufio.Reader, label string, check func(int) error) (*int, bool, error) {
+	for {
+		answer, skip, err := promptAnswer(reader, label)
+		if answer == "" || skip || err != nil {
+			return nil, skip, err
+		}
+
+		number, err := strconv.Atoi(answer)
+		if err != nil {
+			fmt.Printf("  ⚠️  %q is not a whole number\n", answer)
+			continue
+		}
+		if err := check(number); err != nil {
+			fmt.Printf("  ⚠️  %s\n", err)
+			continue
+		}
+		return &number, false, nil
+	}
+}
+
+// promptYesNo asks a yes or no question. The answer is nil when the dimension is skipped.
+func promptYesNo(reader *bufio.Reader, label string) (*bool, bool, error) {
+	for {
+		answer, skip, err := promptAnswer(reader, label)
+		if answer == "" || skip || err != nil {
+			return nil, skip, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+			yes := true
+			return &yes, false, nil
+		case "n", "no":
+			no := false
+			return &no, false, nil
+		}
+		fmt.Printf("  ⚠️  %q is not y or n\n", answer)
+	}
+}
+
+// promptAnswer reads the answer for a measurement: empty to skip the dimension,
+// s to skip the entry, or q to quit.
+func promptAnswer(reader *bufio.Reader, label string) (string, bool, error) {
+	answer, err := prompt(reader, label)
+	if err != nil {
+		return "", false, err
+	}
+
+	switch strings.ToLower(answer) {
+	case "s":
+		return "", true, nil
+	case "q":
+		return "", false, errQuitEvaluation
+	}
+	return answer, false, nil
+}
+
+// printDimensionLevels prints the name of a dimension and the levels of its
+// scale or rubric, if any.
+func printDimensionLevels(dimension pkg.DimensionDeclaration, levels map[int]string) {
+	fmt.Printf("📏 %s\n", dimension.Name)
+	scores := make([]int, 0, len(levels))
+	for score := range levels {
+		scores = append(scores, score)
+	}
+	sort.Ints(scores)
+	for _, score := range scores {
+		fmt.Printf("  %d: %s\n", score, strings.TrimSpace(levels[score]))
+	}
+}
+
+// prompt prints the label and reads a line of input, without its surrounding
+// spaces. The end of the input quits the evaluation session.
+func prompt(reader *bufio.Reader, label string) (string, error) {
+	fmt.Print(label)
+	line, err := reader.ReadString('\n')
+	if errors.Is(err, io.EOF) {
+		if strings.TrimSpace(line) == "" {
+			fmt.Println()
+			return "", errQuitEvaluation
+		}
+		return strings.TrimSpace(line), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+)
+
+// scriptedInput returns a reader answering the prompts with the lines, in order.
+func scriptedInput(lines ...string) *bufio.Reader {
+	return bufio.NewReader(strings.NewReader(strings.Join(lines, "\n")))
+}
+
+func TestPromptNumber(t *testing.T) {
+	below := func(max int) func(int) error {
+		return func(number int) error {
+			if number > max {
+				return fmt.Errorf("%d is above %d", number, max)
+			}
+			return nil
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		input    *bufio.Reader
+		expected *int
+		skip     bool
+		err      error
+	}{
+		{"retries until the number is accepted", scriptedInput("three", "9", " 3 ", ""), intPointer(3), false, nil},
+		{"empty answer skips the dimension", scriptedInput("", ""), nil, false, nil},
+		{"s skips the entry", scriptedInput("S", ""), nil, true, nil},
+		{"q quits", scriptedInput("q", ""), nil, false, errQuitEvaluation},
+		{"end of input quits", scriptedInput(), nil, false, errQuitEvaluation},
+		{"last line without a newline", scriptedInput("4"), intPointer(4), false, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			number, skip, err := promptNumber(tc.input, "score: ", below(5))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected error %v, but got %v", tc.err, err)
+			}
+			if skip != tc.skip {
+				t.Errorf("Expected skip %t, but got %t", tc.skip, skip)
+			}
+			if (number == nil) != (tc.expected == nil) || (number != nil && *number != *tc.expected) {
+				t.Errorf("Expected %v, but got %v", formatIntPointer(tc.expected), formatIntPointer(number))
+			}
+		})
+	}
+}
+
+func TestPromptYesNo(t *testing.T) {
+	testCases := []struct {
+		input    *bufio.Reader
+		expected *bool
+	}{
+		{scriptedInput("Y", ""), boolPointer(true)},
+		{scriptedInput("maybe", "no", ""), boolPointer(false)},
+		{scriptedInput("", ""), nil},
+	}
+
+	for _, tc := range testCases {
+		answer, _, err := promptYesNo(tc.input, "passed? ")
+		if err != nil {
+			t.Fatalf("promptYesNo failed: %s", err)
+		}
+		if (answer == nil) != (tc.expected == nil) || (answer != nil && *answer != *tc.expected) {
+			t.Errorf("Expected %v, but got %v", tc.expected, answer)
+		}
+	}
+}
+
+func TestPromptEvaluationRecord(t *testing.T) {
+	file := validator.EvaluationFile{Filepath: "evaluations/mentor.yaml"}
+	file.Definition.Meta.Dimensions = []pkg.DimensionDeclaration{
+		{Alias: "focus", Name: "focus", Version: "0.1.0", Scale: &pkg.DimensionScale{Min: intPointer(1), Max: intPointer(5)}},
+		{Alias: "setup", Name: "onboarding", Version: "0.1.0", Kind: pkg.MeasurementChecklist, Criteria: []string{"dev environment", "first pull request"}},
+		{Alias: "gate", Name: "onboarding", Version: "0.1.0", Kind: pkg.MeasurementPassFail},
+	}
+
+	t.Run("answers every dimension", func(t *testing.T) {
+		input := scriptedInput(
+			"7", "4", "Stayed on task", "Shipped the parser", "Skipped the tests",
+			"y", "n", "", "", "",
+			"", "",
+		)
+		record, err := promptEvaluationRecord(input, file, nil, "dsu-1")
+		if err != nil {
+			t.Fatalf("promptEvaluationRecord failed: %s", err)
+		}
+		if record.ID != "dsu-1" || len(record.Measurements) != 2 {
+			t.Fatalf("Expected focus and setup measurements of dsu-1, but got %+v", record)
+		}
+
+		focus := record.Measurements[0]
+		if focus.Dimension != "focus" || focus.Score == nil || *focus.Score != 4 {
+			t.Errorf("Expected a focus score of 4, but got %+v", focus)
+		}
+		if focus.Remarks != "Stayed on task" || focus.Wins != "Shipped the parser" || focus.Mistakes != "Skipped the tests" {
+			t.Errorf("Expected the remarks, wins and mistakes as answered, but got %+v", focus)
+		}
+
+		setup := record.Measurements[1]
+		checklist := setup.Checklist.Checklist
+		if setup.Dimension != "setup" || !checklist["dev environment"] || checklist["first pull request"] {
+			t.Errorf("Expected the dev environment criterion met only, but got %+v", setup)
+		}
+	})
+
+	t.Run("s skips the entry", func(t *testing.T) {
+		record, err := promptEvaluationRecord(scriptedInput("3", "", "", "", "s", ""), file, nil, "dsu-2")
+		if err != nil {
+			t.Fatalf("promptEvaluationRecord failed: %s", err)
+		}
+		if len(record.Measurements) != 0 {
+			t.Errorf("Expected no measurements for a skipped entry, but got %+v", record.Measurements)
+		}
+	})
+
+	t.Run("q quits", func(t *testing.T) {
+		if _, err := promptEvaluationRecord(scriptedInput("q", ""), file, nil, "dsu-3"); !errors.Is(err, errQuitEvaluation) {
+			t.Errorf("Expected the session to quit, but got %v", err)
+		}
+	})
+}
+
+func intPointer(value int) *int {
+	return &value
+}
+
+func boolPointer(value bool) *bool {
+	return &value
+}
+
+func formatIntPointer(value *int) string {
+	if value == nil {
+		return "nil"
+	}
+	return fmt.Sprint(*value)
+}
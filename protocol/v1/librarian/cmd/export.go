@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+	"github.com/urfave/cli/v2"
+)
+
+func exportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export DSU entries to other tools",
+		Subcommands: []*cli.Command{
+			exportICSCommand(),
+		},
+	}
+}
+
+func exportICSCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "ics",
+		Usage: "Export DSU entries as all-day events of an iCalendar (.ics) file",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository"),
+			tagFlag(),
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Only export entries on or after this date (YYYY-MM-DD)",
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: "Only export entries on or before this date (YYYY-MM-DD)",
+			},
+			&cli.BoolFlag{
+				Name:  "todos",
+				Usage: "Add a to-do for each DSU entry missing an evaluation",
+			},
+			&cli.StringFlag{
+				Name:  "evaluator",
+				Usage: "With --todos, only count evaluations by this evaluator (name, email or eth)",
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Usage:       "File to write the calendar to",
+				DefaultText: "standard output",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			since, err := parseDateFlag(c, "since")
+			if err != nil {
+				return err
+			}
+
+			until, err := parseDateFlag(c, "until")
+			if err != nil {
+				return err
+			}
+
+			entries, err := validator.GetAllDSUEntries(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get DSU entries: %s", err)
+			}
+			entries = validator.FilterDSUsByTags(entries, c.StringSlice("tag"))
+
+			selected := entries[:0]
+			for _, entry := range entries {
+				if validator.InDateRange(entry.Datetime, since, until) {
+					selected = append(selected, entry)
+				}
+			}
+
+			options := validator.ICSOptions{Stamp: time.Now()}
+			if c.Bool("todos") {
+				missing, err := validator.FindMissingEvaluationsBy(plan, c.String("evaluator"), c.StringSlice("tag"), false)
+				if err != nil {
+					return fmt.Errorf("failed to find missing evaluations: %s", err)
+				}
+				for _, entry := range missing {
+					if validator.InDateRange(entry.Datetime, since, until) {
+						options.MissingEvaluations = append(options.MissingEvaluations, entry)
+					}
+				}
+			}
+
+			path := c.String("output")
+			if path == "" {
+				if err := validator.WriteICS(os.Stdout, selected, options); err != nil {
+					return fmt.Errorf("failed to write calendar: %s", err)
+				}
+				return nil
+			}
+
+			file, err := os.Create(path)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %s", path, err)
+			}
+			if err := validator.WriteICS(file, selected, options); err != nil {
+				file.Close()
+				return fmt.Errorf("failed to write calendar: %s", err)
+			}
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("failed to write %s: %s", path, err)
+			}
+
+			fmt.Printf("📅 Exported %d DSU entries and %d evaluation to-dos to %s\n", len(selected), len(options.MissingEvaluations), path)
+			return nil
+		},
+	}
+}
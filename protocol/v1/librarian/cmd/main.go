@@ -4,16 +4,13 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"sort"
 	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	librarian "github.com/tome-gg/librarian/protocol/v1/librarian"
-	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
 	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
 	"github.com/urfave/cli/v2"
-	"gopkg.in/yaml.v2"
 )
 
 func main() {
@@ -93,6 +90,11 @@ func main() {
 						Name:  "all",
 						Usage: "Show all missing evaluations (default: show last 3 only)",
 					},
+					&cli.StringFlag{
+						Name:  "evaluator",
+						Usage: "Only count evaluations by this evaluator (name, email or eth)",
+					},
+					tagFlag(),
 				},
 				Action: func(c *cli.Context) error {
 					directoryPath := c.String("directory")
@@ -119,23 +121,38 @@ func main() {
 					plan := validator.Init(directory)
 					plan.Init()
 
-					missingEvaluations, err := validator.FindMissingEvaluations(plan, !showAll)
+					evaluator := c.String("evaluator")
+					missingEvaluations, err := validator.FindMissingEvaluationsBy(plan, evaluator, c.StringSlice("tag"), !showAll)
 					if err != nil {
 						return fmt.Errorf("failed to find missing evaluations: %s", err)
 					}
 
+					kind := "self evaluations"
+					if evaluator != "" {
+						kind = fmt.Sprintf("evaluations by %s", evaluator)
+					}
+
 					if len(missingEvaluations) == 0 {
-						fmt.Println("✅ All DSU entries have corresponding self evaluations!")
+						fmt.Printf("✅ All DSU entries have corresponding %s!\n", kind)
 						return nil
 					}
 
+					evaluators, err := validator.GetEvaluators(plan)
+					if err != nil {
+						return fmt.Errorf("failed to get evaluators: %s", err)
+					}
+
 					if showAll {
-						fmt.Printf("Found %d DSU entries without self evaluations (all entries):\n\n", len(missingEvaluations))
+						fmt.Printf("Found %d DSU entries without %s (all entries):\n\n", len(missingEvaluations), kind)
 					} else {
-						fmt.Printf("Found %d DSU entries without self evaluations (last 3, use --all for complete list):\n\n", len(missingEvaluations))
+						fmt.Printf("Found %d DSU entries without %s (last 3, use --all for complete list):\n\n", len(missingEvaluations), kind)
 					}
 					for _, entry := range missingEvaluations {
-						fmt.Printf("UUID: %s\nDate: %s\n\n", entry.ID, entry.Datetime.Format("2006-01-02 15:04:05"))
+						fmt.Printf("UUID: %s\nDate: %s\n", entry.ID, entry.Datetime.Format("2006-01-02 15:04:05"))
+						if names := evaluators[entry.ID]; len(names) > 0 {
+							fmt.Printf("Evaluated by: %s\n", strings.Join(names, ", "))
+						}
+						fmt.Println()
 					}
 
 					return nil
@@ -189,16 +206,7 @@ func main() {
 						return fmt.Errorf("failed to get DSU entry: %s", err)
 					}
 
-					fmt.Printf("UUID: %s\n", entry.ID)
-					fmt.Printf("Date: %s\n", entry.Datetime.Format("2006-01-02 15:04:05"))
-					fmt.Printf("Done Yesterday: %s\n", entry.DoneYesterday)
-					fmt.Printf("Doing Today: %s\n", entry.DoingToday)
-					if entry.Blockers != "" {
-						fmt.Printf("Blockers: %s\n", entry.Blockers)
-					}
-					if entry.Remarks != "" {
-						fmt.Printf("Remarks: %s\n", entry.Remarks)
-					}
+					printDSUEntry(*entry)
 
 					return nil
 				},
@@ -245,20 +253,15 @@ func main() {
 					}
 
 					fmt.Printf("🚀 Latest DSU Entry:\n\n")
-					fmt.Printf("UUID: %s\n", entry.ID)
-					fmt.Printf("Date: %s\n", entry.Datetime.Format("2006-01-02 15:04:05"))
-					fmt.Printf("Done Yesterday: %s\n", entry.DoneYesterday)
-					fmt.Printf("Doing Today: %s\n", entry.DoingToday)
-					if entry.Blockers != "" {
-						fmt.Printf("Blockers: %s\n", entry.Blockers)
-					}
-					if entry.Remarks != "" {
-						fmt.Printf("Remarks: %s\n", entry.Remarks)
-					}
+					printDSUEntry(*entry)
 
 					return nil
 				},
 			},
+			dsuCommand(),
+			searchCommand(),
+			reportCommand(),
+			exportCommand(),
 			{
 				Name:    "completion",
 				Usage:   "Generate shell completion scripts",
@@ -279,7 +282,17 @@ complete -c tome -n "__fish_use_subcommand" -a "get-dsu" -d "Retrieve a DSU entr
 complete -c tome -n "__fish_use_subcommand" -a "get" -d "Retrieve a DSU entry by its UUID"
 complete -c tome -n "__fish_use_subcommand" -a "get-latest" -d "Retrieve the most recent DSU entry by date"
 complete -c tome -n "__fish_use_subcommand" -a "latest" -d "Retrieve the most recent DSU entry by date"
+complete -c tome -n "__fish_use_subcommand" -a "dsu" -d "Create and manage daily stand-up (DSU) entries"
+complete -c tome -n "__fish_use_subcommand" -a "search" -d "Search DSU entries by text, date, tag and blockers"
+complete -c tome -n "__fish_use_subcommand" -a "report" -d "Summarize the DSUs and evaluations of a period"
+complete -c tome -n "__fish_use_subcommand" -a "export" -d "Export DSU entries to other tools"
 complete -c tome -n "__fish_use_subcommand" -a "validate" -d "Validate a directory using the Librarian protocol"
+complete -c tome -n "__fish_use_subcommand" -a "evaluate" -d "Evaluate the DSU entries missing an evaluation, one at a time"
+complete -c tome -n "__fish_use_subcommand" -a "scores" -d "Show the evaluation scores of each dimension over time"
+complete -c tome -n "__fish_use_subcommand" -a "calibration" -d "Compare the scores different evaluators gave the same training entries"
+complete -c tome -n "__fish_use_subcommand" -a "sign" -d "Sign an evaluation file, or some of its records, with your ed25519 key"
+complete -c tome -n "__fish_use_subcommand" -a "verify" -d "Check that signed evaluations were signed with trusted keys and still match their signatures"
+complete -c tome -n "__fish_use_subcommand" -a "dimensions" -d "Display the evaluation dimensions with their rubrics and versions"
 complete -c tome -n "__fish_use_subcommand" -a "completion" -d "Generate shell completion scripts"
 complete -c tome -n "__fish_use_subcommand" -a "help" -d "Shows a list of commands or help for one command"
 
@@ -292,6 +305,8 @@ complete -c tome -n "__fish_seen_subcommand_from missing-evaluations missing get
 
 # Missing evaluations flags
 complete -c tome -n "__fish_seen_subcommand_from missing-evaluations missing" -l all -d "Show all missing evaluations (default: show last 3 only)"
+complete -c tome -n "__fish_seen_subcommand_from missing-evaluations missing" -l evaluator -d "Only count evaluations by this evaluator" -r
+complete -c tome -n "__fish_seen_subcommand_from missing-evaluations missing" -l tag -d "Only include entries with this tag" -r
 
 # UUID flag for get-dsu command
 complete -c tome -n "__fish_seen_subcommand_from get-dsu get" -l uuid -s u -d "UUID of the DSU entry to retrieve" -r
@@ -305,6 +320,97 @@ complete -c tome -n "__fish_seen_subcommand_from init initalize" -l public -d "I
 # Validate command flags
 complete -c tome -n "__fish_seen_subcommand_from validate" -l verbose -d "Enable verbose logging"
 
+# DSU subcommands
+complete -c tome -n "__fish_seen_subcommand_from dsu" -a "new" -d "Scaffold today's DSU entry and open it in \$EDITOR"
+complete -c tome -n "__fish_seen_subcommand_from dsu" -a "edit" -d "Open a DSU entry in \$EDITOR and write it back in place"
+complete -c tome -n "__fish_seen_subcommand_from dsu" -a "remove rm" -d "Remove a DSU entry by its UUID"
+complete -c tome -n "__fish_seen_subcommand_from dsu" -a "list ls" -d "List DSU entries with their blockers and evaluation status"
+complete -c tome -n "__fish_seen_subcommand_from dsu" -a "rotate" -d "Move DSU entries into one file per period"
+complete -c tome -n "__fish_seen_subcommand_from dsu" -a "streak" -d "Show the current and longest DSU streak"
+complete -c tome -n "__fish_seen_subcommand_from dsu" -a "gaps" -d "List working days without a DSU"
+complete -c tome -n "__fish_seen_subcommand_from dsu" -a "blockers" -d "Track blockers across consecutive DSUs"
+complete -c tome -n "__fish_seen_subcommand_from dsu" -a "continuity" -d "Find dropped and unplanned tasks between consecutive DSUs"
+complete -c tome -n "__fish_seen_subcommand_from dsu" -a "import" -d "Import DSUs from CSV, Markdown notes or a Slack export"
+complete -c tome -n "__fish_seen_subcommand_from dsu" -l directory -s d -d "Path to the directory" -r
+complete -c tome -n "__fish_seen_subcommand_from new" -l doing-today -d "Prefill doing_today" -r
+complete -c tome -n "__fish_seen_subcommand_from new" -l blockers -d "Prefill blockers" -r
+complete -c tome -n "__fish_seen_subcommand_from new" -l remarks -d "Prefill remarks" -r
+complete -c tome -n "__fish_seen_subcommand_from new" -l no-edit -d "Write the entry without opening \$EDITOR"
+complete -c tome -n "__fish_seen_subcommand_from edit remove rm" -l uuid -s u -d "UUID of the DSU entry" -r
+complete -c tome -n "__fish_seen_subcommand_from remove rm" -l force -d "Remove the entry even if evaluations reference it"
+complete -c tome -n "__fish_seen_subcommand_from rotate" -l by -d "Rotation period" -xa "quarter month year"
+complete -c tome -n "__fish_seen_subcommand_from rotate" -l max-entries -d "Only rotate files with more entries than this" -r
+complete -c tome -n "__fish_seen_subcommand_from rotate" -l dry-run -d "Show which entries would be moved"
+complete -c tome -n "__fish_seen_subcommand_from list ls blockers streak gaps continuity" -l tag -d "Only include entries with this tag" -r
+complete -c tome -n "__fish_seen_subcommand_from new" -l tag -d "Tag the entry" -r
+complete -c tome -n "__fish_seen_subcommand_from list ls" -l since -d "Only list entries on or after this date (YYYY-MM-DD)" -r
+complete -c tome -n "__fish_seen_subcommand_from list ls" -l until -d "Only list entries on or before this date (YYYY-MM-DD)" -r
+complete -c tome -n "__fish_seen_subcommand_from list ls" -l last -d "Only list the N most recent entries" -r
+complete -c tome -n "__fish_seen_subcommand_from list ls" -l reverse -d "List the most recent entries first"
+complete -c tome -n "__fish_seen_subcommand_from list ls" -l output -s o -d "Output format" -xa "table json yaml csv"
+complete -c tome -n "__fish_seen_subcommand_from blockers" -l all -d "Also show resolved blockers"
+complete -c tome -n "__fish_seen_subcommand_from blockers" -l similarity -d "Minimum text similarity (0 to 1)" -r
+complete -c tome -n "__fish_seen_subcommand_from blockers" -l output -d "Output format" -xa "text json"
+complete -c tome -n "__fish_seen_subcommand_from continuity" -l since -d "Only check entries on or after this date (YYYY-MM-DD)" -r
+complete -c tome -n "__fish_seen_subcommand_from continuity" -l until -d "Only check entries on or before this date (YYYY-MM-DD)" -r
+complete -c tome -n "__fish_seen_subcommand_from continuity" -l similarity -d "Minimum text similarity (0 to 1)" -r
+complete -c tome -n "__fish_seen_subcommand_from continuity" -l all -d "Also show DSUs where every planned task was done"
+complete -c tome -n "__fish_seen_subcommand_from continuity" -l output -d "Output format" -xa "text json"
+complete -c tome -n "__fish_seen_subcommand_from gaps" -l since -d "First day to check (YYYY-MM-DD)" -r
+complete -c tome -n "__fish_seen_subcommand_from gaps" -l until -d "Last day to check (YYYY-MM-DD)" -r
+complete -c tome -n "__fish_seen_subcommand_from import" -l from -d "Format of the imported file or directory" -xa "csv markdown slack-json"
+complete -c tome -n "__fish_seen_subcommand_from import" -l tag -d "Tag every imported entry" -r
+complete -c tome -n "__fish_seen_subcommand_from import" -l user -d "Only import the Slack messages of this user" -r
+complete -c tome -n "__fish_seen_subcommand_from import" -l dry-run -d "Show the entries that would be imported"
+
+# Search command flags
+complete -c tome -n "__fish_seen_subcommand_from search" -l directory -s d -d "Path to the directory" -r
+complete -c tome -n "__fish_seen_subcommand_from search" -l since -d "Only search entries on or after this date" -r
+complete -c tome -n "__fish_seen_subcommand_from search" -l until -d "Only search entries on or before this date" -r
+complete -c tome -n "__fish_seen_subcommand_from search" -l tag -d "Only search entries with this tag" -r
+complete -c tome -n "__fish_seen_subcommand_from search" -l has-blockers -d "Only search entries reporting blockers"
+complete -c tome -n "__fish_seen_subcommand_from search" -l field -d "Only search this field" -xa "done_yesterday doing_today blockers remarks"
+complete -c tome -n "__fish_seen_subcommand_from report" -l directory -s d -d "Path to the directory" -r
+complete -c tome -n "__fish_seen_subcommand_from report" -l period -d "Period covered by the report" -xa "week month quarter"
+complete -c tome -n "__fish_seen_subcommand_from report" -l date -d "Any day of the period to report on" -r
+complete -c tome -n "__fish_seen_subcommand_from report" -l output -s o -d "Output format" -xa "md html"
+complete -c tome -n "__fish_seen_subcommand_from report" -l tag -d "Only include entries with this tag" -r
+complete -c tome -n "__fish_seen_subcommand_from report" -a "mentor-feedback" -d "Summarize the meta evaluations of the mentor's teaching"
+complete -c tome -n "__fish_seen_subcommand_from evaluate" -l directory -s d -d "Path to the directory" -r
+complete -c tome -n "__fish_seen_subcommand_from evaluate" -l file -d "Evaluation file to append the evaluations to" -r
+complete -c tome -n "__fish_seen_subcommand_from scores" -l directory -s d -d "Path to the directory" -r
+complete -c tome -n "__fish_seen_subcommand_from scores" -l dimension -d "Only show this dimension" -r
+complete -c tome -n "__fish_seen_subcommand_from scores" -l evaluator -d "Only count evaluations by this evaluator" -r
+complete -c tome -n "__fish_seen_subcommand_from scores" -l since -d "Only count DSU entries on or after this date" -r
+complete -c tome -n "__fish_seen_subcommand_from scores" -l until -d "Only count DSU entries on or before this date" -r
+complete -c tome -n "__fish_seen_subcommand_from scores" -l tag -d "Only count DSU entries with this tag" -r
+complete -c tome -n "__fish_seen_subcommand_from scores" -l window -d "Number of scores the rolling average spans" -r
+complete -c tome -n "__fish_seen_subcommand_from scores" -l output -s o -d "Output format" -xa "text json csv"
+complete -c tome -n "__fish_seen_subcommand_from calibration" -l directory -s d -d "Path to the directory" -r
+complete -c tome -n "__fish_seen_subcommand_from calibration" -l dimension -d "Only compare this dimension" -r
+complete -c tome -n "__fish_seen_subcommand_from calibration" -l evaluator -d "Only compare this evaluator with the others" -r
+complete -c tome -n "__fish_seen_subcommand_from calibration" -l since -d "Only compare DSU entries on or after this date" -r
+complete -c tome -n "__fish_seen_subcommand_from calibration" -l until -d "Only compare DSU entries on or before this date" -r
+complete -c tome -n "__fish_seen_subcommand_from calibration" -l top -d "Number of biggest disagreements to show" -r
+complete -c tome -n "__fish_seen_subcommand_from calibration" -l output -s o -d "Output format" -xa "text json"
+complete -c tome -n "__fish_seen_subcommand_from sign" -l key -d "Path to the ed25519 signing key" -r
+complete -c tome -n "__fish_seen_subcommand_from sign" -l record -d "Only sign the evaluation of this training ID" -r
+complete -c tome -n "__fish_seen_subcommand_from sign" -l public-key -d "Print the public key of the signing key"
+complete -c tome -n "__fish_seen_subcommand_from verify" -l directory -s d -d "Path to the directory" -r
+complete -c tome -n "__fish_seen_subcommand_from verify" -l trusted-key -d "Also trust signatures made with this public key" -r
+complete -c tome -n "__fish_seen_subcommand_from dimensions" -l directory -s d -d "Path to the directory" -r
+complete -c tome -n "__fish_seen_subcommand_from dimensions" -l all -d "Show the whole dimension catalogue"
+complete -c tome -n "__fish_seen_subcommand_from export" -a "ics" -d "Export DSU entries as an iCalendar file"
+complete -c tome -n "__fish_seen_subcommand_from ics" -l directory -s d -d "Path to the directory" -r
+complete -c tome -n "__fish_seen_subcommand_from ics" -l tag -d "Only export entries with this tag" -r
+complete -c tome -n "__fish_seen_subcommand_from ics" -l since -d "Only export entries on or after this date (YYYY-MM-DD)" -r
+complete -c tome -n "__fish_seen_subcommand_from ics" -l until -d "Only export entries on or before this date (YYYY-MM-DD)" -r
+complete -c tome -n "__fish_seen_subcommand_from ics" -l todos -d "Add a to-do for each DSU missing an evaluation"
+complete -c tome -n "__fish_seen_subcommand_from ics" -l evaluator -d "Only count evaluations by this evaluator" -r
+complete -c tome -n "__fish_seen_subcommand_from ics" -l output -s o -d "File to write the calendar to" -r
+complete -c tome -n "__fish_seen_subcommand_from search" -l output -d "Output format" -xa "text json"
+complete -c tome -n "__fish_seen_subcommand_from search" -l no-color -d "Disable highlighting of matches"
+
 # Completion subcommands
 complete -c tome -n "__fish_seen_subcommand_from completion" -a "fish" -d "Generate fish completion script"`)
 							return nil
@@ -385,119 +491,12 @@ complete -c tome -n "__fish_seen_subcommand_from completion" -a "fish" -d "Gener
 					return nil
 				},
 			},
-			{
-				Name:    "dimensions",
-				Usage:   "Display all evaluation dimensions with their aliases, names, and labels",
-				Flags: []cli.Flag{
-					&cli.StringFlag{
-						Name:        "directory",
-						Aliases:     []string{"d"},
-						Usage:       "Target directory to scan for evaluation files",
-						DefaultText: "current directory",
-					},
-				},
-				Action: func(cCtx *cli.Context) error {
-					directoryPath := cCtx.String("directory")
-					if directoryPath == "" {
-						currentDir, err := os.Getwd()
-						if err != nil {
-							return fmt.Errorf("failed to get current directory: %s", err)
-						}
-						directoryPath = currentDir
-					}
-
-					directory, err := librarian.Parse(directoryPath)
-					if err != nil {
-						return fmt.Errorf("failed to parse directory %s: %s", directoryPath, err)
-					}
-
-					plan := validator.Init(directory)
-					plan.Init()
-
-					// Find evaluation files and extract dimensions
-					dimensions := make(map[string]struct {
-						Alias      string
-						Name       string
-						Version    string
-						Definition string
-					})
-
-					for _, file := range plan.Files {
-						if !strings.Contains(file.Filepath, "evaluations") || !strings.HasSuffix(file.Filepath, ".yaml") {
-							continue
-						}
-
-						fileBytes, err := os.ReadFile(file.Filepath)
-						if err != nil {
-							continue
-						}
-
-						var result pkg.EvaluationDefinition[pkg.StandardMeasurement]
-						err = yaml.Unmarshal(fileBytes, &result)
-						if err != nil {
-							continue
-						}
-
-						if result.Tomegg.Type != "evaluations" {
-							continue
-						}
-
-						for _, dim := range result.Meta.Dimensions {
-							dimensions[dim.Alias] = struct {
-								Alias      string
-								Name       string
-								Version    string
-								Definition string
-							}{
-								Alias:      dim.Alias,
-								Name:       dim.Name,
-								Version:    dim.Version,
-								Definition: dim.Definition,
-							}
-						}
-					}
-
-					if len(dimensions) == 0 {
-						fmt.Println("No evaluation dimensions found in this repository.")
-						return nil
-					}
-
-					fmt.Println("📏 Evaluation Dimensions")
-					fmt.Println("========================")
-					fmt.Println()
-					fmt.Println("Field Definitions:")
-					fmt.Println("  • Alias: Short identifier used in measurements (e.g., 'focus')")
-					fmt.Println("  • Name:  Machine-readable snake_case name used in URLs/definitions")
-					fmt.Println("  • Label: Human-readable title for display purposes")
-					fmt.Println()
-
-					// Sort dimensions by alias for consistent output
-					aliases := make([]string, 0, len(dimensions))
-					for alias := range dimensions {
-						aliases = append(aliases, alias)
-					}
-					sort.Strings(aliases)
-
-					for _, alias := range aliases {
-						dim := dimensions[alias]
-						
-						// Create human-readable label from snake_case name
-						labelWords := strings.Split(dim.Name, "_")
-						for i, word := range labelWords {
-							labelWords[i] = strings.Title(word)
-						}
-						label := strings.Join(labelWords, " ")
-
-						fmt.Printf("Alias: %s\n", dim.Alias)
-						fmt.Printf("Name:  %s\n", dim.Name)
-						fmt.Printf("Label: %s\n", label)
-						fmt.Printf("URL:   %s\n", dim.Definition)
-						fmt.Println()
-					}
-
-					return nil
-				},
-			},
+			dimensionsCommand(),
+			evaluateCommand(),
+			scoresCommand(),
+			calibrationCommand(),
+			signCommand(),
+			verifyCommand(),
 		},
 	}
 
@@ -0,0 +1,420 @@
+package main
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+	"github.com/urfave/cli/v2"
+)
+
+var reportFuncs = map[string]interface{}{
+	"date": func(t time.Time) string {
+		return t.Format(pkg.DateLayout)
+	},
+	"weekday": func(t time.Time) string {
+		return t.Weekday().String()
+	},
+	"average": func(f float64) string {
+		return fmt.Sprintf("%.2f", f)
+	},
+	"trim":        strings.TrimSpace,
+	"items":       validator.SplitListItems,
+	"hasBlockers": validator.HasBlockers,
+	"field":       pkg.FormatFieldValue,
+}
+
+const markdownReportTemplate = `# {{ .Title }}
+
+{{ date .Report.Start }} → {{ date .Report.End }}
+
+- DSUs written: {{ len .Report.Entries }}
+- Working days without a DSU: {{ len .Report.MissingDays }}
+- Open blockers: {{ len .Report.OpenBlockers }}
+
+## Evaluation scores
+{{ if .Report.Dimensions }}
+| Dimension | Evaluations | Average | Min | Max |
+| --- | --- | --- | --- | --- |
+{{- range .Report.Dimensions }}
+| {{ .Dimension }} | {{ .Count }} | {{ average .Average }} | {{ .Min }} | {{ .Max }} |
+{{- end }}
+{{ else }}
+No evaluations for this period.
+{{ end }}
+## Open blockers
+{{ if .Report.OpenBlockers }}
+{{ range .Report.OpenBlockers -}}
+- {{ .Text }} (reported on {{ .Occurrences }} DSUs since {{ date .FirstSeen }})
+{{ end -}}
+{{ else }}
+No open blockers. 🎉
+{{ end }}
+## Days without a DSU
+{{ if .Report.MissingDays }}
+{{ range .Report.MissingDays -}}
+- {{ date . }} ({{ weekday . }})
+{{ end -}}
+{{ else }}
+A DSU was written on every working day. 🔥
+{{ end }}
+## Daily stand-ups
+{{ range .Report.Entries }}
+### {{ date .Datetime }} ({{ weekday .Datetime }})
+
+**Done yesterday**
+
+{{ trim .DoneYesterday }}
+
+**Doing today**
+
+{{ trim .DoingToday }}
+
+**Blockers**
+
+{{ if hasBlockers .DSUReport }}{{ trim .Blockers }}{{ else }}None{{ end }}
+{{ if trim .Remarks }}
+**Remarks**
+
+{{ trim .Remarks }}
+{{ end }}
+{{- if .Fields }}
+{{ range $name, $value := .Fields }}- **{{ $name }}**: {{ field $value }}
+{{ end }}
+{{- end }}
+{{- if .Measurements }}
+**Evaluations**
+
+{{ range .Measurements -}}
+- {{ .Dimension }}: {{ .Value }} by {{ .Evaluator }}{{ if trim .Remarks }} — {{ trim .Remarks }}{{ end }}
+{{ end -}}
+{{ end -}}
+{{ end }}`
+
+const htmlReportTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: sans-serif; max-width: 50em; margin: 2em auto; line-height: 1.5; color: #222; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.25em 0.75em; text-align: left; }
+article { border-top: 1px solid #eee; }
+</style>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+<p>{{ date .Report.Start }} → {{ date .Report.End }}</p>
+<ul>
+<li>DSUs written: {{ len .Report.Entries }}</li>
+<li>Working days without a DSU: {{ len .Report.MissingDays }}</li>
+<li>Open blockers: {{ len .Report.OpenBlockers }}</li>
+</ul>
+
+<h2>Evaluation scores</h2>
+{{ if .Report.Dimensions -}}
+<table>
+<tr><th>Dimension</th><th>Evaluations</th><th>Average</th><th>Min</th><th>Max</th></tr>
+{{ range .Report.Dimensions -}}
+<tr><td>{{ .Dimension }}</td><td>{{ .Count }}</td><td>{{ average .Average }}</td><td>{{ .Min }}</td><td>{{ .Max }}</td></tr>
+{{ end -}}
+</table>
+{{- else -}}
+<p>No evaluations for this period.</p>
+{{- end }}
+
+<h2>Open blockers</h2>
+{{ if .Report.OpenBlockers -}}
+<ul>
+{{ range .Report.OpenBlockers -}}
+<li>{{ .Text }} (reported on {{ .Occurrences }} DSUs since {{ date .FirstSeen }})</li>
+{{ end -}}
+</ul>
+{{- else -}}
+<p>No open blockers. 🎉</p>
+{{- end }}
+
+<h2>Days without a DSU</h2>
+{{ if .Report.MissingDays -}}
+<ul>
+{{ range .Report.MissingDays -}}
+<li>{{ date . }} ({{ weekday . }})</li>
+{{ end -}}
+</ul>
+{{- else -}}
+<p>A DSU was written on every working day. 🔥</p>
+{{- end }}
+
+<h2>Daily stand-ups</h2>
+{{ range .Report.Entries -}}
+<article>
+<h3>{{ date .Datetime }} ({{ weekday .Datetime }})</h3>
+<h4>Done yesterday</h4>
+<ul>{{ range items .DoneYesterday }}<li>{{ . }}</li>{{ end }}</ul>
+<h4>Doing today</h4>
+<ul>{{ range items .DoingToday }}<li>{{ . }}</li>{{ end }}</ul>
+<h4>Blockers</h4>
+{{ if hasBlockers .DSUReport }}<ul>{{ range items .Blockers }}<li>{{ . }}</li>{{ end }}</ul>{{ else }}<p>None</p>{{ end }}
+{{ if trim .Remarks }}<h4>Remarks</h4>
+<p>{{ trim .Remarks }}</p>
+{{ end -}}
+{{ if .Fields }}<dl>{{ range $name, $value := .Fields }}<dt>{{ $name }}</dt><dd>{{ field $value }}</dd>{{ end }}</dl>
+{{ end -}}
+{{ if .Measurements }}<h4>Evaluations</h4>
+<ul>{{ range .Measurements }}<li>{{ .Dimension }}: {{ .Value }} by {{ .Evaluator }}{{ if trim .Remarks }} — {{ trim .Remarks }}{{ end }}</li>{{ end }}</ul>
+{{ end -}}
+</article>
+{{ end -}}
+</body>
+</html>
+`
+
+const markdownMentorFeedbackTemplate = `# {{ .Title }}
+
+{{ date .Report.Start }} → {{ date .Report.End }}
+
+- DSUs rated: {{ len .Report.Entries }}
+
+## Teaching ratings
+{{ if .Report.Dimensions }}
+| Dimension | Ratings | Average | Min | Max |
+| --- | --- | --- | --- | --- |
+{{- range .Report.Dimensions }}
+| {{ .Dimension }} | {{ .Count }} | {{ average .Average }} | {{ .Min }} | {{ .Max }} |
+{{- end }}
+{{ else }}
+No meta evaluations for this period.
+{{ end }}
+{{- range .Report.Entries }}
+### {{ date .Datetime }} ({{ weekday .Datetime }}) — {{ .Mentor }}
+
+{{ range .Measurements -}}
+- {{ .Dimension }}: {{ .Value }}{{ if trim .Remarks }} — {{ trim .Remarks }}{{ end }}
+{{ end -}}
+{{ end }}`
+
+const htmlMentorFeedbackTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: sans-serif; max-width: 50em; margin: 2em auto; line-height: 1.5; color: #222; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.25em 0.75em; text-align: left; }
+article { border-top: 1px solid #eee; }
+</style>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+<p>{{ date .Report.Start }} → {{ date .Report.End }}</p>
+<p>DSUs rated: {{ len .Report.Entries }}</p>
+
+<h2>Teaching ratings</h2>
+{{ if .Report.Dimensions -}}
+<table>
+<tr><th>Dimension</th><th>Ratings</th><th>Average</th><th>Min</th><th>Max</th></tr>
+{{ range .Report.Dimensions -}}
+<tr><td>{{ .Dimension }}</td><td>{{ .Count }}</td><td>{{ average .Average }}</td><td>{{ .Min }}</td><td>{{ .Max }}</td></tr>
+{{ end -}}
+</table>
+{{- else -}}
+<p>No meta evaluations for this period.</p>
+{{- end }}
+{{ range .Report.Entries -}}
+<article>
+<h3>{{ date .Datetime }} ({{ weekday .Datetime }}) — {{ .Mentor }}</h3>
+<ul>{{ range .Measurements }}<li>{{ .Dimension }}: {{ .Value }}{{ if trim .Remarks }} — {{ trim .Remarks }}{{ end }}</li>{{ end }}</ul>
+</article>
+{{ end -}}
+</body>
+</html>
+`
+
+// reportView is the data rendered by the report templates.
+type reportView struct {
+	Title  string
+	Report validator.ProgressReport
+}
+
+// mentorFeedbackView is the data rendered by the mentor-feedback templates.
+type mentorFeedbackView struct {
+	Title  string
+	Report validator.MentorFeedbackReport
+}
+
+// reportFlags are the flags shared by the report commands.
+func reportFlags() []cli.Flag {
+	return []cli.Flag{
+		directoryFlag("Path to the tome repository"),
+		&cli.StringFlag{
+			Name:  "period",
+			Usage: "Period covered by the report: week, month or quarter",
+			Value: string(validator.ReportByWeek),
+		},
+		&cli.StringFlag{
+			Name:        "date",
+			Usage:       "Any day of the period to report on (YYYY-MM-DD)",
+			DefaultText: "today",
+		},
+		&cli.StringFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Usage:   "Output format: md or html",
+			Value:   "md",
+		},
+		tagFlag(),
+	}
+}
+
+func reportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "report",
+		Usage: "Summarize the DSUs, evaluation scores, open blockers and missed days of a period",
+		Flags: reportFlags(),
+		Subcommands: []*cli.Command{
+			mentorFeedbackCommand(),
+		},
+		Action: func(c *cli.Context) error {
+			period, err := validator.ParseReportPeriod(c.String("period"))
+			if err != nil {
+				return err
+			}
+
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			calendar, err := pkg.LoadCalendar(plan.Config)
+			if err != nil {
+				return fmt.Errorf("failed to load calendar: %s", err)
+			}
+
+			now, err := today(plan)
+			if err != nil {
+				return err
+			}
+
+			date, err := reportDate(c, now)
+			if err != nil {
+				return err
+			}
+
+			dsuFiles, err := validator.GetDSUFiles(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get DSU files: %s", err)
+			}
+			dsuFiles = validator.FilterDSUFilesByTags(dsuFiles, c.StringSlice("tag"))
+
+			evaluationFiles, err := validator.GetEvaluationFiles(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get evaluation files: %s", err)
+			}
+
+			report := validator.BuildProgressReport(dsuFiles, evaluationFiles, calendar, period, date, now)
+
+			return writeReport(os.Stdout, c.String("output"), report)
+		},
+	}
+}
+
+func mentorFeedbackCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "mentor-feedback",
+		Usage: "Summarize the meta evaluations of the mentor's teaching over a period",
+		Flags: reportFlags(),
+		Action: func(c *cli.Context) error {
+			period, err := validator.ParseReportPeriod(c.String("period"))
+			if err != nil {
+				return err
+			}
+
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			now, err := today(plan)
+			if err != nil {
+				return err
+			}
+
+			date, err := reportDate(c, now)
+			if err != nil {
+				return err
+			}
+
+			dsuFiles, err := validator.GetDSUFiles(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get DSU files: %s", err)
+			}
+			dsuFiles = validator.FilterDSUFilesByTags(dsuFiles, c.StringSlice("tag"))
+
+			evaluationFiles, err := validator.GetEvaluationFiles(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get evaluation files: %s", err)
+			}
+
+			report := validator.BuildMentorFeedbackReport(dsuFiles, evaluationFiles, period, date)
+
+			view := mentorFeedbackView{
+				Title:  fmt.Sprintf("%s mentor feedback", periodTitle(report.Period)),
+				Report: report,
+			}
+			return renderReport(os.Stdout, c.String("output"), view, markdownMentorFeedbackTemplate, htmlMentorFeedbackTemplate)
+		},
+	}
+}
+
+// reportDate returns the --date flag, today when unset.
+func reportDate(c *cli.Context, now time.Time) (time.Time, error) {
+	date, err := parseDateFlag(c, "date")
+	if err != nil {
+		return time.Time{}, err
+	}
+	if date.IsZero() {
+		return now, nil
+	}
+	return date, nil
+}
+
+// periodTitle capitalizes the name of the period, e.g. Weekly.
+func periodTitle(period validator.ReportPeriod) string {
+	titles := map[validator.ReportPeriod]string{
+		validator.ReportByWeek:    "Weekly",
+		validator.ReportByMonth:   "Monthly",
+		validator.ReportByQuarter: "Quarterly",
+	}
+	return titles[period]
+}
+
+// writeReport renders the report as Markdown or HTML.
+func writeReport(w io.Writer, format string, report validator.ProgressReport) error {
+	view := reportView{
+		Title:  periodTitle(report.Period) + " report",
+		Report: report,
+	}
+	return renderReport(w, format, view, markdownReportTemplate, htmlReportTemplate)
+}
+
+// renderReport renders the view with the Markdown or HTML template.
+func renderReport(w io.Writer, format string, view interface{}, markdown string, html string) error {
+	switch format {
+	case "md", "markdown":
+		tmpl := texttemplate.Must(texttemplate.New("report").Funcs(reportFuncs).Parse(markdown))
+		return tmpl.Execute(w, view)
+	case "html":
+		tmpl := htmltemplate.Must(htmltemplate.New("report").Funcs(reportFuncs).Parse(html))
+		return tmpl.Execute(w, view)
+	}
+
+	return fmt.Errorf("unsupported output format %q: expected md or html", format)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+)
+
+func TestWriteReportTitle(t *testing.T) {
+	testCases := []struct {
+		period   validator.ReportPeriod
+		expected string
+	}{
+		{validator.ReportByWeek, "# Weekly report\n"},
+		{validator.ReportByMonth, "# Monthly report\n"},
+		{validator.ReportByQuarter, "# Quarterly report\n"},
+	}
+
+	for _, tc := range testCases {
+		report := validator.ProgressReport{
+			Period: tc.period,
+			Start:  time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			End:    time.Date(2024, 7, 7, 0, 0, 0, 0, time.UTC),
+		}
+
+		var output bytes.Buffer
+		if err := writeReport(&output, "md", report); err != nil {
+			t.Fatalf("writeReport failed: %s", err)
+		}
+		if !strings.HasPrefix(output.String(), tc.expected) {
+			t.Errorf("Expected the %s report to start with %q, but got %q", tc.period, tc.expected, output.String())
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+	"github.com/urfave/cli/v2"
+)
+
+var trendArrows = map[validator.ScoreTrend]string{
+	validator.TrendUp:   "↗ up",
+	validator.TrendDown: "↘ down",
+	validator.TrendFlat: "→ flat",
+}
+
+func scoresCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "scores",
+		Usage: "Show the evaluation scores of each dimension over time, with rolling averages and trends",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository"),
+			&cli.StringFlag{
+				Name:  "dimension",
+				Usage: "Only show this dimension",
+			},
+			&cli.StringFlag{
+				Name:  "evaluator",
+				Usage: "Only count evaluations by this evaluator (name, email or eth)",
+			},
+			tagFlag(),
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Only count DSU entries on or after this date (YYYY-MM-DD)",
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: "Only count DSU entries on or before this date (YYYY-MM-DD)",
+			},
+			&cli.IntFlag{
+				Name:  "window",
+				Usage: "Number of scores the rolling average spans",
+				Value: validator.DefaultRollingWindow,
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Output format: text, json or csv",
+				Value:   "text",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			since, err := parseDateFlag(c, "since")
+			if err != nil {
+				return err
+			}
+			until, err := parseDateFlag(c, "until")
+			if err != nil {
+				return err
+			}
+			if c.Int("window") < 1 {
+				return fmt.Errorf("invalid --window %d: expected at least 1", c.Int("window"))
+			}
+
+			dsuFiles, err := validator.GetDSUFiles(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get DSU files: %s", err)
+			}
+			dsuFiles = validator.FilterDSUFilesByTags(dsuFiles, c.StringSlice("tag"))
+			evaluationFiles, err := validator.GetEvaluationFiles(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get evaluation files: %s", err)
+			}
+
+			series := validator.BuildScoreSeries(dsuFiles, evaluationFiles, validator.ScoreQuery{
+				Dimension: c.String("dimension"),
+				Evaluator: c.String("evaluator"),
+				Since:     since,
+				Until:     until,
+				Window:    c.Int("window"),
+			})
+
+			return writeScoreSeries(c.String("output"), series)
+		},
+	}
+}
+
+func writeScoreSeries(format string, series []validator.ScoreSeries) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(series)
+
+	case "csv":
+		writer := csv.NewWriter(os.Stdout)
+		writer.Write([]string{"dimension", "date", "id", "evaluator", "score", "rolling_average"})
+		for _, s := range series {
+			for _, point := range s.Points {
+				writer.Write([]string{
+					s.Dimension,
+					point.Date.Format(time.RFC3339),
+					point.ID,
+					point.Evaluator,
+					strconv.Itoa(point.Score),
+					strconv.FormatFloat(point.RollingAverage, 'f', 2, 64),
+				})
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	case "text":
+		if len(series) == 0 {
+			fmt.Println("No evaluation scores found.")
+			return nil
+		}
+
+		fmt.Println("📈 Scores over time")
+		fmt.Println("===================")
+		fmt.Println()
+
+		for _, s := range series {
+			min, max := s.Range()
+			last := s.Points[len(s.Points)-1]
+
+			fmt.Printf("%s by %s  %s\n", s.Dimension, s.Evaluator, trendArrows[s.Trend])
+			fmt.Printf("  %s\n", validator.Sparkline(s.Scores(), float64(min), float64(max)))
+			fmt.Printf("  %s → %s, scale %d to %d\n", s.Start().Format(pkg.DateLayout), s.End().Format(pkg.DateLayout), min, max)
+
+			writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(writer, "  Evaluations:\t%d\n", len(s.Points))
+			fmt.Fprintf(writer, "  Average:\t%.2f\n", s.Average)
+			fmt.Fprintf(writer, "  Latest:\t%d (rolling average %.2f)\n", last.Score, last.RollingAverage)
+			fmt.Fprintf(writer, "  Change per week:\t%+.2f\n", s.Slope)
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			fmt.Println()
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported output format %q: expected text, json or csv", format)
+}
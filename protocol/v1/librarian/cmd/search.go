@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	highlightStart = "\033[1;33m"
+	highlightEnd   = "\033[0m"
+)
+
+func searchCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "search",
+		Usage:     "Search DSU entries by text, date, tag and blockers",
+		ArgsUsage: "<query>...",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository"),
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Only search entries on or after this date (YYYY-MM-DD)",
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: "Only search entries on or before this date (YYYY-MM-DD)",
+			},
+			tagFlag(),
+			&cli.BoolFlag{
+				Name:  "has-blockers",
+				Usage: "Only search entries reporting blockers",
+			},
+			&cli.StringSliceFlag{
+				Name:  "field",
+				Usage: fmt.Sprintf("Only search this field (repeatable): %s", strings.Join(validator.SearchableDSUFields, ", ")),
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Output format: text or json",
+				Value: "text",
+			},
+			&cli.BoolFlag{
+				Name:  "no-color",
+				Usage: "Disable highlighting of matches",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return fmt.Errorf("no search query specified. Use --help to see usage")
+			}
+
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			since, err := parseDateFlag(c, "since")
+			if err != nil {
+				return err
+			}
+			until, err := parseDateFlag(c, "until")
+			if err != nil {
+				return err
+			}
+
+			dsuFiles, err := validator.GetDSUFiles(plan)
+			if err != nil {
+				return fmt.Errorf("failed to get DSU files: %s", err)
+			}
+
+			matches, err := validator.SearchDSUs(dsuFiles, validator.DSUQuery{
+				Terms:       c.Args().Slice(),
+				Fields:      c.StringSlice("field"),
+				Since:       since,
+				Until:       until,
+				Tags:        c.StringSlice("tag"),
+				HasBlockers: c.Bool("has-blockers"),
+			})
+			if err != nil {
+				return err
+			}
+
+			switch c.String("output") {
+			case "json":
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(matches)
+			case "text":
+			default:
+				return fmt.Errorf("unsupported output format %q: expected text or json", c.String("output"))
+			}
+
+			if len(matches) == 0 {
+				fmt.Println("No DSU entries found.")
+				return nil
+			}
+
+			color := !c.Bool("no-color") && os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout)
+
+			fmt.Printf("Found %d matching DSU entries:\n\n", len(matches))
+			for _, match := range matches {
+				fmt.Printf("UUID: %s\nDate: %s\n", match.ID, match.Date.Format(pkg.DateLayout))
+				for _, snippet := range match.Snippets {
+					fmt.Printf("  %s: %s\n", snippet.Field, highlight(snippet.Text, c.Args().Slice(), color))
+				}
+				fmt.Println()
+			}
+
+			return nil
+		},
+	}
+}
+
+// isTerminal returns true if the file is a terminal rather than a pipe or a file.
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// highlight wraps every case-insensitive occurrence of the terms in ANSI bold.
+func highlight(text string, terms []string, color bool) string {
+	if !color {
+		return text
+	}
+
+	var b strings.Builder
+	offset := 0
+	for _, r := range validator.TermRanges(text, terms) {
+		b.WriteString(text[offset:r[0]])
+		b.WriteString(highlightStart)
+		b.WriteString(text[r[0]:r[1]])
+		b.WriteString(highlightEnd)
+		offset = r[1]
+	}
+	b.WriteString(text[offset:])
+
+	return b.String()
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+	"github.com/urfave/cli/v2"
+)
+
+func signingKeyFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:        "key",
+		Usage:       "Path to the ed25519 signing key",
+		DefaultText: "tome/ed25519.pem in the user's configuration directory",
+	}
+}
+
+// signingKeyPath returns the path of the --key flag, or of the default signing key.
+func signingKeyPath(c *cli.Context) (string, error) {
+	if path := c.String("key"); path != "" {
+		return path, nil
+	}
+	return validator.DefaultSigningKeyPath()
+}
+
+func signCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "sign",
+		Usage:     "Sign an evaluation file, or some of its records, with your ed25519 key",
+		ArgsUsage: "<evaluation file>",
+		Flags: []cli.Flag{
+			signingKeyFlag(),
+			&cli.StringSliceFlag{
+				Name:  "record",
+				Usage: "Only sign the evaluation of this training ID (repeatable)",
+			},
+			&cli.BoolFlag{
+				Name:  "public-key",
+				Usage: "Print the public key of the signing key and exit",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			keyPath, err := signingKeyPath(c)
+			if err != nil {
+				return fmt.Errorf("failed to locate the signing key: %s", err)
+			}
+
+			key, created, err := validator.LoadSigningKey(keyPath, true)
+			if err != nil {
+				return fmt.Errorf("failed to load the signing key: %s", err)
+			}
+			if created {
+				fmt.Printf("🔑 Created a new signing key at %s\n", keyPath)
+			}
+
+			if c.Bool("public-key") {
+				fmt.Println(validator.PublicKeyOf(key))
+				return nil
+			}
+
+			if c.NArg() != 1 {
+				return fmt.Errorf("expected the evaluation file to sign. Use --help to see usage")
+			}
+			path := c.Args().First()
+
+			file, err := validator.ReadEvaluationFile(path)
+			if err != nil {
+				return err
+			}
+
+			fileBytes, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			signature, err := validator.SignEvaluation(fileBytes, key, c.StringSlice("record"))
+			if err != nil {
+				return fmt.Errorf("failed to sign %s: %s", path, err)
+			}
+			if err := validator.AddSignature(path, signature); err != nil {
+				return err
+			}
+
+			fmt.Printf("✍️  Signed %s of %s as %s with key %s\n", validator.SignedContent(signature), path, file.EvaluatorName(), signature.PublicKey)
+			return nil
+		},
+	}
+}
+
+func verifyCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "verify",
+		Usage:     "Check that signed evaluations were signed with trusted keys and still match their signatures",
+		ArgsUsage: "[evaluation file]...",
+		Flags: []cli.Flag{
+			directoryFlag("Path to the tome repository, whose tome.yaml pins the keys of evaluators"),
+			&cli.StringSliceFlag{
+				Name:  "trusted-key",
+				Usage: "Also trust signatures made with this base64 public key (repeatable)",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			plan, err := loadPlan(c.String("directory"))
+			if err != nil {
+				return err
+			}
+
+			paths := c.Args().Slice()
+			if len(paths) == 0 {
+				files, err := validator.GetEvaluationFiles(plan)
+				if err != nil {
+					return fmt.Errorf("failed to get evaluation files: %s", err)
+				}
+				for _, file := range files {
+					paths = append(paths, file.Filepath)
+				}
+			}
+
+			failed := 0
+			for _, path := range paths {
+				file, err := validator.ReadEvaluationFile(path)
+				if err != nil {
+					return err
+				}
+				fileBytes, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+
+				trusted, pinned := validator.TrustedKeys(plan.Config, file, c.StringSlice("trusted-key"))
+				signatures := file.Definition.Meta.Signatures
+				if len(signatures) == 0 && !pinned {
+					fmt.Printf("➖ %s: unsigned\n", path)
+					continue
+				}
+				for _, signature := range signatures {
+					if err := validator.VerifySignature(fileBytes, signature, trusted); err != nil {
+						failed++
+						fmt.Printf("❌ %s: %s\n", path, err)
+						continue
+					}
+					fmt.Printf("✅ %s: %s signed by %s with key %s\n", path, validator.SignedContent(signature), file.EvaluatorName(), signature.PublicKey)
+				}
+				if pinned {
+					if err := validator.RequireSignedEvaluations(fileBytes, file, trusted); err != nil {
+						failed++
+						fmt.Printf("❌ %s: %s\n", path, err)
+					}
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d signature checks failed", failed)
+			}
+			return nil
+		},
+	}
+}
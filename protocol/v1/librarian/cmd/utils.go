@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	librarian "github.com/tome-gg/librarian/protocol/v1/librarian"
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	validator "github.com/tome-gg/librarian/protocol/v1/librarian/validator"
+	"github.com/urfave/cli/v2"
+)
+
+// directoryFlag is the --directory flag shared by commands that operate on a repository.
+func directoryFlag(usage string) *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:        "directory",
+		Aliases:     []string{"d"},
+		Usage:       usage,
+		DefaultText: "current directory",
+	}
+}
+
+// tagFlag is the --tag flag shared by commands that filter DSU entries by tag.
+func tagFlag() *cli.StringSliceFlag {
+	return &cli.StringSliceFlag{
+		Name:  "tag",
+		Usage: "Only include entries with this tag, from the entry or its file's meta.tags (repeatable)",
+	}
+}
+
+// loadPlan parses the target directory and returns its initialized validation plan.
+func loadPlan(directoryPath string) (*pkg.ValidationPlan, error) {
+	if directoryPath == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current working directory: %s", err)
+		}
+		directoryPath = wd
+	}
+
+	// Trim trailing slash
+	if len(directoryPath) > 1 && directoryPath[len(directoryPath)-1] == '/' {
+		directoryPath = directoryPath[:len(directoryPath)-1]
+	}
+
+	directory, err := librarian.Parse(directoryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse directory: %s", err)
+	}
+
+	plan := validator.Init(directory)
+	plan.Init()
+
+	return plan, nil
+}
+
+// parseDateFlag parses a YYYY-MM-DD flag value, returning the zero time when unset.
+func parseDateFlag(c *cli.Context, name string) (time.Time, error) {
+	value := c.String(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse(pkg.DateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s date %q: expected YYYY-MM-DD", name, value)
+	}
+
+	return date, nil
+}
+
+// today returns the current date in the repository's configured timezone.
+func today(plan *pkg.ValidationPlan) (time.Time, error) {
+	loc, err := plan.Config.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return pkg.DateOf(time.Now().In(loc)), nil
+}
+
+// openEditor opens the file in the user's $EDITOR and waits for it to close.
+func openEditor(path string) error {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+		if runtime.GOOS == "windows" {
+			editor = []string{"notepad"}
+		}
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %s", editor[0], err)
+	}
+
+	return nil
+}
+
+// printDSUEntry prints a DSU entry, as shown by get-dsu.
+func printDSUEntry(entry pkg.DSUReport) {
+	fmt.Printf("UUID: %s\n", entry.ID)
+	fmt.Printf("Date: %s\n", entry.Datetime.Format("2006-01-02 15:04:05"))
+	fmt.Printf("Done Yesterday: %s\n", entry.DoneYesterday)
+	fmt.Printf("Doing Today: %s\n", entry.DoingToday)
+	if entry.Blockers != "" {
+		fmt.Printf("Blockers: %s\n", entry.Blockers)
+	}
+	if entry.Remarks != "" {
+		fmt.Printf("Remarks: %s\n", entry.Remarks)
+	}
+	for _, name := range entry.CustomFieldNames() {
+		fmt.Printf("%s: %s\n", name, pkg.FormatFieldValue(entry.Fields[name]))
+	}
+}
@@ -0,0 +1,162 @@
+package pkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DateLayout is the layout used for dates in configuration and command flags.
+const DateLayout = "2006-01-02"
+
+var defaultWeekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+
+type (
+	// CalendarConfig defines the working-day calendar in tome.yaml.
+	CalendarConfig struct {
+		// Weekdays lists the days of the week that are working days.
+		Weekdays []string `yaml:"weekdays"`
+		// Holidays lists the holiday files, relative to the repository root.
+		Holidays []string `yaml:"holidays"`
+		// Leave lists the date ranges where no DSU is expected.
+		Leave []LeaveRange `yaml:"leave"`
+	}
+
+	// LeaveRange defines an inclusive range of days off.
+	LeaveRange struct {
+		From   string `yaml:"from"`
+		To     string `yaml:"to"`
+		Reason string `yaml:"reason"`
+	}
+
+	// HolidayFile defines the contents of a holiday file.
+	HolidayFile struct {
+		Holidays []struct {
+			Date string `yaml:"date"`
+			Name string `yaml:"name"`
+		} `yaml:"holidays"`
+	}
+
+	// Calendar answers which days are working days.
+	Calendar struct {
+		weekdays map[time.Weekday]bool
+		holidays map[string]string
+		leave    []dateRange
+	}
+
+	dateRange struct {
+		from   time.Time
+		to     time.Time
+		reason string
+	}
+)
+
+// LoadCalendar builds the working-day calendar declared in tome.yaml.
+// Without a calendar declaration, Monday to Friday are working days.
+func LoadCalendar(c *TomeConfig) (*Calendar, error) {
+	calendar := &Calendar{
+		weekdays: map[time.Weekday]bool{},
+		holidays: map[string]string{},
+	}
+
+	weekdays := c.Calendar.Weekdays
+	if len(weekdays) == 0 {
+		weekdays = defaultWeekdays
+	}
+	for _, name := range weekdays {
+		weekday, err := parseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		calendar.weekdays[weekday] = true
+	}
+
+	for _, path := range c.Calendar.Holidays {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(c.root, path)
+		}
+
+		fileBytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read holiday file: %s", err)
+		}
+
+		var result HolidayFile
+		if err := yaml.Unmarshal(fileBytes, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse holiday file %s: %s", path, err)
+		}
+
+		for _, holiday := range result.Holidays {
+			date, err := time.Parse(DateLayout, holiday.Date)
+			if err != nil {
+				return nil, fmt.Errorf("invalid holiday date %q in %s", holiday.Date, path)
+			}
+			calendar.holidays[date.Format(DateLayout)] = holiday.Name
+		}
+	}
+
+	for _, leave := range c.Calendar.Leave {
+		from, err := time.Parse(DateLayout, leave.From)
+		if err != nil {
+			return nil, fmt.Errorf("invalid leave start %q: expected a YYYY-MM-DD date", leave.From)
+		}
+		to, err := time.Parse(DateLayout, leave.To)
+		if err != nil {
+			return nil, fmt.Errorf("invalid leave end %q: expected a YYYY-MM-DD date", leave.To)
+		}
+		if to.Before(from) {
+			return nil, fmt.Errorf("invalid leave from %s to %s: it ends before it starts", leave.From, leave.To)
+		}
+		calendar.leave = append(calendar.leave, dateRange{from: from, to: to, reason: leave.Reason})
+	}
+
+	return calendar, nil
+}
+
+func parseWeekday(name string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) || strings.EqualFold(day.String()[:3], name) {
+			return day, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("invalid weekday %q", name)
+}
+
+// DateOf returns the calendar date of t, as written in its own timezone.
+func DateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// IsWorkingDay returns true if a DSU is expected on the date.
+func (c *Calendar) IsWorkingDay(t time.Time) bool {
+	_, off := c.DayOff(t)
+	return !off
+}
+
+// DayOff returns why the date is not a working day, if it is not.
+func (c *Calendar) DayOff(t time.Time) (string, bool) {
+	date := DateOf(t)
+
+	if !c.weekdays[date.Weekday()] {
+		return date.Weekday().String(), true
+	}
+
+	if name, ok := c.holidays[date.Format(DateLayout)]; ok {
+		return fmt.Sprintf("holiday: %s", name), true
+	}
+
+	for _, leave := range c.leave {
+		if !date.Before(leave.from) && !date.After(leave.to) {
+			if leave.reason == "" {
+				return "leave", true
+			}
+			return fmt.Sprintf("leave: %s", leave.reason), true
+		}
+	}
+
+	return "", false
+}
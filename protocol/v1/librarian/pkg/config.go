@@ -0,0 +1,168 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigFilename is the name of the repository configuration file.
+const ConfigFilename = "tome.yaml"
+
+// DefaultTrainingDirectory is used when tome.yaml does not declare content.training.
+const DefaultTrainingDirectory = "training/"
+
+// DefaultEvaluationsDirectory is used when tome.yaml does not declare content.evaluations.
+const DefaultEvaluationsDirectory = "evaluations/"
+
+// DefaultDimensionsDirectory is used when tome.yaml does not declare content.dimensions.
+const DefaultDimensionsDirectory = "dimensions/"
+
+type (
+	// TomeConfig defines the repository configuration found in tome.yaml.
+	TomeConfig struct {
+		Version int    `yaml:"version"`
+		Type    string `yaml:"type"`
+
+		Content struct {
+			Training    string `yaml:"training"`
+			Evaluations string `yaml:"evaluations"`
+			// Dimensions holds the repository's own dimension catalogue.
+			Dimensions string `yaml:"dimensions"`
+		} `yaml:"content"`
+
+		// Datetime defines how DSU datetimes are stamped and interpreted.
+		Datetime DatetimeConfig `yaml:"datetime"`
+
+		// DSU defines how DSU training files are organized.
+		DSU DSUConfig `yaml:"dsu"`
+
+		// Calendar defines which days are expected to have a DSU.
+		Calendar CalendarConfig `yaml:"calendar"`
+
+		// Tags defines the vocabulary of tags training entries may use, matched
+		// case-insensitively like tag filters. Any tag is allowed when empty.
+		Tags []string `yaml:"tags"`
+
+		// Evaluators pins the public keys evaluators sign their evaluations with.
+		// Evaluation files by a pinned evaluator must be signed with its key.
+		Evaluators []PinnedEvaluator `yaml:"evaluators"`
+
+		// root defines the directory where tome.yaml was found.
+		root string
+	}
+
+	// DatetimeConfig defines the datetime settings of a repository.
+	DatetimeConfig struct {
+		// Timezone is the IANA timezone used when stamping new entries, and
+		// when reading datetimes written without an offset.
+		Timezone string `yaml:"timezone"`
+		// Strict rejects ambiguous datetimes, and datetimes that match none of
+		// the allowed layouts when any are configured.
+		Strict bool `yaml:"strict"`
+		// DateOrder resolves numeric dates such as 03/04/2024: mdy or dmy.
+		DateOrder string `yaml:"date_order"`
+		// Layouts lists the allowed Go time layouts, tried in order. The first
+		// layout is used when stamping new entries.
+		Layouts []string `yaml:"layouts"`
+	}
+
+	// PinnedEvaluator pins the public key of an evaluator, matched against the
+	// evaluator of evaluation files by name, email or eth.
+	PinnedEvaluator struct {
+		Evaluator `yaml:",inline"`
+		// PublicKey is the base64 ed25519 public key, as printed by sign --public-key.
+		PublicKey string `yaml:"public_key"`
+	}
+
+	// DSUConfig defines the DSU settings of a repository.
+	DSUConfig struct {
+		// Rotation splits DSU entries into one file per period (quarter, month or year).
+		Rotation string `yaml:"rotation"`
+	}
+)
+
+// LoadConfig reads the tome.yaml found in the root directory. A missing
+// tome.yaml is not an error; the default configuration is returned instead.
+func LoadConfig(root string) (*TomeConfig, error) {
+	config := &TomeConfig{root: root}
+
+	fileBytes, err := os.ReadFile(filepath.Join(root, ConfigFilename))
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+
+	if err := yaml.Unmarshal(fileBytes, config); err != nil {
+		return config, fmt.Errorf("failed to parse %s: %s", ConfigFilename, err)
+	}
+
+	return config, nil
+}
+
+// Root returns the directory where the configuration was loaded from.
+func (c *TomeConfig) Root() string {
+	return c.root
+}
+
+// TrainingDirectory returns the absolute path of the training directory.
+func (c *TomeConfig) TrainingDirectory() string {
+	dir := c.Content.Training
+	if dir == "" {
+		dir = DefaultTrainingDirectory
+	}
+	return filepath.Join(c.root, dir)
+}
+
+// EvaluationsDirectory returns the absolute path of the evaluations directory.
+func (c *TomeConfig) EvaluationsDirectory() string {
+	dir := c.Content.Evaluations
+	if dir == "" {
+		dir = DefaultEvaluationsDirectory
+	}
+	return filepath.Join(c.root, dir)
+}
+
+// DimensionsDirectory returns the absolute path of the dimension catalogue directory.
+func (c *TomeConfig) DimensionsDirectory() string {
+	dir := c.Content.Dimensions
+	if dir == "" {
+		dir = DefaultDimensionsDirectory
+	}
+	return filepath.Join(c.root, dir)
+}
+
+// Location returns the configured timezone, falling back to the local timezone.
+func (c *TomeConfig) Location() (*time.Location, error) {
+	if c.Datetime.Timezone == "" {
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(c.Datetime.Timezone)
+	if err != nil {
+		return time.Local, fmt.Errorf("invalid timezone %q: %s", c.Datetime.Timezone, err)
+	}
+
+	return loc, nil
+}
+
+// IsAllowedTag returns true if the tag is part of the tag vocabulary, ignoring
+// case as tag filters do.
+func (c *TomeConfig) IsAllowedTag(tag string) bool {
+	if len(c.Tags) == 0 {
+		return true
+	}
+	for _, allowed := range c.Tags {
+		if strings.EqualFold(allowed, tag) {
+			return true
+		}
+	}
+	return false
+}
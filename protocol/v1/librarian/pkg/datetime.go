@@ -0,0 +1,153 @@
+package pkg
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/araddon/dateparse"
+)
+
+const (
+	// DateOrderMonthFirst reads 03/04/2024 as March 4.
+	DateOrderMonthFirst = "mdy"
+	// DateOrderDayFirst reads 03/04/2024 as April 3.
+	DateOrderDayFirst = "dmy"
+)
+
+var numericDatePattern = regexp.MustCompile(`^(\d{1,2})[/.\-](\d{1,2})[/.\-](\d{2,4})\b`)
+
+// AmbiguousDatetimeError is returned in strict mode for numeric dates that
+// read differently month-first and day-first.
+type AmbiguousDatetimeError struct {
+	Value      string
+	MonthFirst time.Time
+	DayFirst   time.Time
+}
+
+func (e *AmbiguousDatetimeError) Error() string {
+	return fmt.Sprintf("ambiguous datetime %q: it reads as %s month-first (mdy) or as %s day-first (dmy); set datetime.date_order in tome.yaml or write the date as YYYY-MM-DD",
+		e.Value, e.MonthFirst.Format(DateLayout), e.DayFirst.Format(DateLayout))
+}
+
+// DatetimeParser parses DSU datetimes according to the datetime settings of tome.yaml.
+type DatetimeParser struct {
+	config DatetimeConfig
+	// loc is the default timezone; nil keeps the legacy behaviour of reading
+	// values without an offset as UTC.
+	loc *time.Location
+}
+
+// NewDatetimeParser creates a parser for the datetime settings.
+func NewDatetimeParser(config DatetimeConfig) (*DatetimeParser, error) {
+	parser := &DatetimeParser{config: config}
+
+	switch config.DateOrder {
+	case "", DateOrderMonthFirst, DateOrderDayFirst:
+	default:
+		return nil, fmt.Errorf("invalid date_order %q: expected %s or %s", config.DateOrder, DateOrderMonthFirst, DateOrderDayFirst)
+	}
+
+	if config.Timezone != "" {
+		loc, err := time.LoadLocation(config.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %s", config.Timezone, err)
+		}
+		parser.loc = loc
+	}
+
+	return parser, nil
+}
+
+// DatetimeParser returns the parser of the datetime settings.
+func (c *TomeConfig) DatetimeParser() (*DatetimeParser, error) {
+	return NewDatetimeParser(c.Datetime)
+}
+
+// In returns a copy of the parser reading values without an offset in loc.
+func (p *DatetimeParser) In(loc *time.Location) *DatetimeParser {
+	parser := *p
+	parser.loc = loc
+	return &parser
+}
+
+// Parse parses a datetime. Values without an offset are read in the default
+// timezone. In strict mode, values must match one of the allowed layouts when
+// any are configured, and ambiguous numeric dates are rejected unless a date
+// order is configured.
+func (p *DatetimeParser) Parse(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	loc := p.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	for _, layout := range p.config.Layouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	if p.config.Strict && len(p.config.Layouts) > 0 {
+		return time.Time{}, fmt.Errorf("datetime %q does not match any of the allowed layouts: %s", value, strings.Join(p.config.Layouts, ", "))
+	}
+
+	if match := numericDatePattern.FindStringSubmatch(value); match != nil {
+		first, _ := strconv.Atoi(match[1])
+		second, _ := strconv.Atoi(match[2])
+
+		switch p.config.DateOrder {
+		case DateOrderMonthFirst:
+			return p.parseAny(value, true)
+		case DateOrderDayFirst:
+			return p.parseAny(value, false)
+		}
+
+		switch {
+		case first > 12:
+			return p.parseAny(value, false)
+		case second > 12:
+			return p.parseAny(value, true)
+		case p.config.Strict && first != second:
+			monthFirst, _ := p.parseAny(value, true)
+			dayFirst, _ := p.parseAny(value, false)
+			return time.Time{}, &AmbiguousDatetimeError{Value: value, MonthFirst: monthFirst, DayFirst: dayFirst}
+		}
+	}
+
+	if p.loc == nil {
+		return dateparse.ParseAny(value)
+	}
+	return dateparse.ParseIn(value, p.loc)
+}
+
+// ParseEntry parses the datetime of a training entry, if any.
+func (p *DatetimeParser) ParseEntry(id string, raw string) (time.Time, error) {
+	if strings.TrimSpace(raw) == "" {
+		return time.Time{}, nil
+	}
+
+	dateTime, err := p.Parse(raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse date of entry %s: %w", id, err)
+	}
+	return dateTime, nil
+}
+
+func (p *DatetimeParser) parseAny(value string, monthFirst bool) (time.Time, error) {
+	loc := p.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	return dateparse.ParseIn(value, loc, dateparse.PreferMonthFirst(monthFirst), dateparse.RetryAmbiguousDateWithSwap(!p.config.Strict))
+}
+
+// StampLayout returns the layout used to stamp new DSU entries: the first
+// allowed layout when any are configured, otherwise RFC 3339.
+func (c DatetimeConfig) StampLayout() string {
+	if len(c.Layouts) > 0 {
+		return c.Layouts[0]
+	}
+	return time.RFC3339
+}
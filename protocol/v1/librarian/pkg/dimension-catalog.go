@@ -0,0 +1,185 @@
+package pkg
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// OfficialDimensionsURL is the base URL of the definitions of the official dimensions.
+const OfficialDimensionsURL = "https://protocol.tome.gg/dimensions"
+
+//go:embed dimensions/*.yaml
+var officialDimensions embed.FS
+
+type (
+	// CatalogDimension describes a dimension of the catalogue, in a file of its own.
+	CatalogDimension struct {
+		Name        string `yaml:"name"`
+		Label       string `yaml:"label"`
+		Description string `yaml:"description"`
+		// Definition is the base URL of the versioned definitions of the
+		// dimension, defaulting to the official one.
+		Definition string `yaml:"definition"`
+		// Versions lists the history of the dimension, oldest first.
+		Versions []DimensionVersion `yaml:"versions"`
+
+		// Official is true for the dimensions embedded in the librarian.
+		Official bool `yaml:"-"`
+		// Source is the file the dimension was read from.
+		Source string `yaml:"-"`
+	}
+
+	// DimensionVersion describes a version of a dimension.
+	DimensionVersion struct {
+		Version string `yaml:"version"`
+		Date    string `yaml:"date"`
+		Changes string `yaml:"changes"`
+		// Rubric describes what each score level means.
+		Rubric map[int]string `yaml:"rubric"`
+	}
+
+	// DimensionCatalog holds the official dimensions and those of the repository.
+	DimensionCatalog struct {
+		dimensions map[string]*CatalogDimension
+	}
+)
+
+// LoadDimensionCatalog reads the official dimensions, and the dimensions found
+// in the dimensions directory of the repository, if any. A repository cannot
+// redefine an official dimension.
+func LoadDimensionCatalog(c *TomeConfig) (*DimensionCatalog, error) {
+	catalog := &DimensionCatalog{dimensions: map[string]*CatalogDimension{}}
+
+	official, err := fs.Glob(officialDimensions, "dimensions/*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range official {
+		fileBytes, err := officialDimensions.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := catalog.add(fileBytes, path.Base(name), true); err != nil {
+			return nil, err
+		}
+	}
+
+	dir := c.DimensionsDirectory()
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return catalog, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dimension catalogue: %s", err)
+	}
+	for _, entry := range entries {
+		if ext := filepath.Ext(entry.Name()); entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		source := filepath.Join(dir, entry.Name())
+		fileBytes, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dimension %s: %s", source, err)
+		}
+		if err := catalog.add(fileBytes, source, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return catalog, nil
+}
+
+func (c *DimensionCatalog) add(fileBytes []byte, source string, official bool) error {
+	dimension := &CatalogDimension{}
+	if err := yaml.Unmarshal(fileBytes, dimension); err != nil {
+		return fmt.Errorf("failed to parse dimension %s: %s", source, err)
+	}
+	dimension.Official = official
+	dimension.Source = source
+
+	if strings.TrimSpace(dimension.Name) == "" {
+		return fmt.Errorf("invalid dimension %s: missing name", source)
+	}
+	if len(dimension.Versions) == 0 {
+		return fmt.Errorf("invalid dimension %s: missing versions", dimension.Name)
+	}
+	seen := map[string]bool{}
+	for _, version := range dimension.Versions {
+		if version.Version == "" {
+			return fmt.Errorf("invalid dimension %s: version without a version number", dimension.Name)
+		}
+		if seen[version.Version] {
+			return fmt.Errorf("invalid dimension %s: version %s is listed more than once", dimension.Name, version.Version)
+		}
+		seen[version.Version] = true
+	}
+
+	if existing, ok := c.dimensions[dimension.Name]; ok {
+		if existing.Official {
+			return fmt.Errorf("invalid dimension %s: %s is an official dimension", source, dimension.Name)
+		}
+		return fmt.Errorf("invalid dimension %s: %s is already defined by %s", source, dimension.Name, existing.Source)
+	}
+
+	c.dimensions[dimension.Name] = dimension
+	return nil
+}
+
+// Lookup returns the dimension with the given name.
+func (c *DimensionCatalog) Lookup(name string) (*CatalogDimension, bool) {
+	dimension, ok := c.dimensions[name]
+	return dimension, ok
+}
+
+// Dimensions returns every dimension of the catalogue, sorted by name.
+func (c *DimensionCatalog) Dimensions() []*CatalogDimension {
+	dimensions := make([]*CatalogDimension, 0, len(c.dimensions))
+	for _, dimension := range c.dimensions {
+		dimensions = append(dimensions, dimension)
+	}
+	sort.Slice(dimensions, func(i, j int) bool {
+		return dimensions[i].Name < dimensions[j].Name
+	})
+	return dimensions
+}
+
+// Version returns the given version of the dimension.
+func (d *CatalogDimension) Version(version string) (*DimensionVersion, bool) {
+	for i := range d.Versions {
+		if d.Versions[i].Version == version {
+			return &d.Versions[i], true
+		}
+	}
+	return nil, false
+}
+
+// Latest returns the most recent version of the dimension.
+func (d *CatalogDimension) Latest() DimensionVersion {
+	return d.Versions[len(d.Versions)-1]
+}
+
+// DefinitionURL returns the URL of the definition of the given version.
+func (d *CatalogDimension) DefinitionURL(version string) string {
+	base := d.Definition
+	if base == "" {
+		base = OfficialDimensionsURL + "/" + d.Name
+	}
+	return strings.TrimSuffix(base, "/") + "/" + version
+}
+
+// DisplayLabel returns the label of the dimension, its name when it has none.
+func (d *CatalogDimension) DisplayLabel() string {
+	if d.Label == "" {
+		return d.Name
+	}
+	return d.Label
+}
@@ -0,0 +1,60 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const mockCatalogDimension = `name: grit
+label: Grit
+description: Keeping at hard problems.
+definition: https://example.com/dimensions/grit
+versions:
+  - version: 0.1.0
+    rubric:
+      1: Gave up early.
+  - version: 0.2.0
+    changes: Clarified the rubric.
+    rubric:
+      1: Gave up at the first obstacle.
+      3: Kept going with help.
+`
+
+func TestLoadDimensionCatalog(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "dimensions")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "grit.yaml"), []byte(mockCatalogDimension), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(root)
+	if err != nil {
+		t.Fatalf("failed to load config: %s", err)
+	}
+
+	catalog, err := LoadDimensionCatalog(config)
+	if err != nil {
+		t.Fatalf("LoadDimensionCatalog failed: %s", err)
+	}
+
+	focus, ok := catalog.Lookup("focus")
+	if !ok || !focus.Official || focus.DefinitionURL("0.1.0") != "https://protocol.tome.gg/dimensions/focus/0.1.0" {
+		t.Errorf("Expected the official focus dimension, but got %+v", focus)
+	}
+
+	grit, ok := catalog.Lookup("grit")
+	if !ok || grit.Official || grit.Latest().Version != "0.2.0" || grit.DefinitionURL("0.2.0") != "https://example.com/dimensions/grit/0.2.0" {
+		t.Errorf("Expected the repository's grit dimension, but got %+v", grit)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "focus.yaml"), []byte("name: focus\nversions:\n  - version: 9.9.9\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDimensionCatalog(config); err == nil {
+		t.Error("Expected an error for a repository redefining an official dimension")
+	}
+}
@@ -0,0 +1,85 @@
+package pkg
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// FieldTypeString is a free text custom field.
+	FieldTypeString = "string"
+	// FieldTypeInt is a whole number custom field.
+	FieldTypeInt = "int"
+	// FieldTypeEnum is a custom field restricted to a set of values.
+	FieldTypeEnum = "enum"
+	// FieldTypeList is a custom field holding a list of text values.
+	FieldTypeList = "list"
+)
+
+// DSUBuiltinFields lists the DSU fields defined by the DSU format, which custom fields cannot redefine.
+var DSUBuiltinFields = []string{"id", "datetime", "tags", "remarks", "done_yesterday", "doing_today", "blockers"}
+
+type (
+	// FieldDefinition declares a custom field of the entries of a training file.
+	FieldDefinition struct {
+		Name        string `yaml:"name"`
+		Type        string `yaml:"type"`
+		Required    bool   `yaml:"required"`
+		Description string `yaml:"description"`
+		// Values lists the allowed values of an enum field.
+		Values []string `yaml:"values"`
+	}
+
+	// FieldSchema is a file declaring custom fields shared by several training files.
+	FieldSchema struct {
+		Fields []FieldDefinition `yaml:"fields"`
+	}
+)
+
+// CustomFieldNames returns the names of the custom fields of the entry, sorted.
+func (r DSUReport) CustomFieldNames() []string {
+	names := make([]string, 0, len(r.Fields))
+	for name := range r.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FormatFieldValue formats a custom field value as text, joining list items with commas.
+func FormatFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = FormatFieldValue(item)
+		}
+		return strings.Join(items, ", ")
+	case string:
+		return v
+	}
+	return fmt.Sprint(value)
+}
+
+// PlainFieldValue returns a custom field value with its YAML mappings keyed by
+// strings, so that it can be encoded as JSON as well as YAML.
+func PlainFieldValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		plain := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			plain[fmt.Sprint(key)] = PlainFieldValue(item)
+		}
+		return plain
+	case []interface{}:
+		plain := make([]interface{}, len(v))
+		for i, item := range v {
+			plain[i] = PlainFieldValue(item)
+		}
+		return plain
+	}
+	return value
+}
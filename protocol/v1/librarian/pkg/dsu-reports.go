@@ -1,11 +1,8 @@
 package pkg
 
 import (
-	"fmt"
 	"strings"
 	"time"
-
-	"github.com/araddon/dateparse"
 )
 
 // DSUReport ...
@@ -17,24 +14,75 @@ type DSUReport struct {
 	DoneYesterday   string `yaml:"done_yesterday"`
 	DoingToday      string `yaml:"doing_today"`
 	Blockers        string `yaml:"blockers"`
+	Tags            []string `yaml:"tags"`
+
+	// Fields holds the custom fields declared in meta.format.fields, keyed by name.
+	Fields map[string]interface{} `yaml:",inline"`
+
+	// FileTags defines the tags applied by the meta.tags of the training file.
+	FileTags []string `yaml:"-"`
+
+	// DatetimeErr holds the error of parsing the datetime of the entry, if any.
+	DatetimeErr error `yaml:"-"`
 }
 
-// UnmarshalYAML unmarshals the data from a YAML byte data.
+// UnparsedDSUReport is a DSUReport decoded without parsing its datetime, for
+// callers parsing it with the datetime settings of their repository.
+type UnparsedDSUReport DSUReport
+
+// UnmarshalYAML unmarshals the data from a YAML byte data, parsing the
+// datetime with the default datetime settings. Use ParseDatetime to parse it
+// with the settings of a repository.
 func (r *DSUReport) UnmarshalYAML(unmarshal func(interface{}) error) error {
-	type dsuReportAlias DSUReport
-	alias := dsuReportAlias(*r)
+	alias := UnparsedDSUReport(*r)
 	if err := unmarshal(&alias); err != nil {
 		return err
 	}
 	*r = DSUReport(alias)
 
-	if dateStr := strings.TrimSpace(alias.DatetimeRaw); dateStr != "" {
-		dateTime, err := dateparse.ParseAny(dateStr)
-		if err != nil {
-			return fmt.Errorf("failed to parse date: %s", err)
+	parser, err := NewDatetimeParser(DatetimeConfig{})
+	if err != nil {
+		return err
+	}
+	return r.ParseDatetime(parser)
+}
+
+// EffectiveTags returns the tags of the training file followed by the entry's own tags.
+func (r DSUReport) EffectiveTags() []string {
+	tags := []string{}
+	seen := map[string]bool{}
+	for _, tag := range append(append([]string{}, r.FileTags...), r.Tags...) {
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// HasTags returns true if every wanted tag is one of the effective tags of the entry.
+func (r DSUReport) HasTags(wanted []string) bool {
+	tags := r.EffectiveTags()
+	for _, w := range wanted {
+		found := false
+		for _, tag := range tags {
+			if strings.EqualFold(tag, w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
 		}
-		r.Datetime = dateTime
 	}
+	return true
+}
 
-	return nil
-}
\ No newline at end of file
+// ParseDatetime parses the datetime of the entry with the datetime parser of
+// its repository.
+func (r *DSUReport) ParseDatetime(parser *DatetimeParser) error {
+	dateTime, err := parser.ParseEntry(r.ID, r.DatetimeRaw)
+	r.Datetime = dateTime
+	r.DatetimeErr = err
+	return err
+}
@@ -0,0 +1,27 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestDSUReportUnmarshalYAML(t *testing.T) {
+	var report DSUReport
+	if err := yaml.Unmarshal([]byte("id: e1\ndatetime: 2024-07-01T09:30:00+08:00\n"), &report); err != nil {
+		t.Fatalf("failed to decode DSU report: %s", err)
+	}
+	if !report.Datetime.Equal(time.Date(2024, time.July, 1, 1, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected the datetime to be parsed with the default settings, but got %s", report.Datetime)
+	}
+
+	if err := yaml.Unmarshal([]byte("id: e2\ndatetime: someday\n"), &report); err == nil {
+		t.Errorf("Expected an unparseable datetime to fail decoding")
+	}
+
+	var unparsed UnparsedDSUReport
+	if err := yaml.Unmarshal([]byte("id: e2\ndatetime: someday\n"), &unparsed); err != nil || unparsed.DatetimeRaw != "someday" {
+		t.Errorf("Expected the unparsed report to keep the raw datetime, but got %+v, %v", unparsed, err)
+	}
+}
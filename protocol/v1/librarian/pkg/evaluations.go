@@ -1,37 +1,178 @@
 package pkg
 
+import "fmt"
+
+const (
+	// EvaluationSubtypeStandard is the subtype of evaluations of the apprentice's training.
+	EvaluationSubtypeStandard = ""
+	// EvaluationSubtypeMeta is the subtype of evaluations of the mentor's
+	// teaching, written by the apprentice for each training entry.
+	EvaluationSubtypeMeta = "meta"
+
+	// TeachingDimension is the dimension rated by meta evaluations.
+	TeachingDimension = "teaching"
+)
+
 // EvaluationDefinition defines the training definition for a daily stand up
 type EvaluationDefinition[E any] struct {
 	Tomegg struct {
 		Type       string `yaml:"type"`
+		Subtype    string `yaml:"subtype"`
 		Version    string `yaml:"version"`
 		Definition string `yaml:"definition"`
 	} `yaml:"tomegg"`
 
 	Meta struct {
-		Dimensions []struct {
-			Alias      string `yaml:"alias"`
-			Name       string `yaml:"name"`
-			Version    string `yaml:"version"`
-			Definition string `yaml:"definition"`
-		} `yaml:"dimensions"`
+		Evaluator *Evaluator `yaml:"evaluator"`
+		// Mentor is the mentor whose teaching is rated by meta evaluations.
+		Mentor     *Evaluator             `yaml:"mentor"`
+		Dimensions []DimensionDeclaration `yaml:"dimensions"`
+		// Signatures lists the signatures of the file, or of some of its records.
+		Signatures []Signature `yaml:"signatures"`
 	} `yaml:"meta"`
 
 	Evaluations []EvaluationRecord[E] `yaml:"evaluations"`
 }
 
+// Evaluator identifies who wrote the evaluations of a file.
+type Evaluator struct {
+	Name    string           `yaml:"name"`
+	Socials EvaluatorSocials `yaml:"socials"`
+}
+
+// EvaluatorSocials lists the handles an evaluator can be reached or verified by.
+type EvaluatorSocials struct {
+	Email string `yaml:"email"`
+	// Eth is an ENS name (e.g. sapalo.eth) or a 0x account address.
+	Eth string `yaml:"eth"`
+}
+
+// SignatureAlgorithm is the algorithm of the signatures of evaluation files.
+const SignatureAlgorithm = "ed25519"
+
+// Signature is an evaluator's signature of the canonical form of an evaluation
+// file, or of some of its records. Keys and signatures are base64 encoded.
+type Signature struct {
+	Algorithm string `yaml:"algorithm"`
+	PublicKey string `yaml:"public_key"`
+	Value     string `yaml:"value"`
+	// Records lists the IDs of the signed evaluation records; the whole file
+	// is signed when empty.
+	Records []string `yaml:"records"`
+}
+
+// DimensionDeclaration declares a dimension measured by an evaluation file.
+type DimensionDeclaration struct {
+	Alias      string `yaml:"alias"`
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Definition string `yaml:"definition"`
+	// Scale bounds the scores of the dimension, when set.
+	Scale *DimensionScale `yaml:"scale"`
+	// Kind is the kind of measurement of the dimension, standard by default.
+	Kind string `yaml:"kind"`
+	// Criteria lists the criteria of a checklist dimension.
+	Criteria []string `yaml:"criteria"`
+}
+
+// MeasurementKind returns the kind of measurement of the dimension.
+func (d DimensionDeclaration) MeasurementKind() string {
+	if d.Kind == "" {
+		return MeasurementStandard
+	}
+	return d.Kind
+}
+
+// DimensionScale defines the scores allowed for a dimension.
+type DimensionScale struct {
+	// Min and Max bound the scores, a missing bound leaving its side
+	// unbounded. When both are left out, the scale spans its allowed values,
+	// else its labelled levels.
+	Min *int `yaml:"min"`
+	Max *int `yaml:"max"`
+	// Step restricts scores to min, min + step, min + 2 * step and so on.
+	Step int `yaml:"step"`
+	// Allowed restricts scores to the listed values.
+	Allowed []int `yaml:"allowed"`
+	// Labels names the levels of the scale, e.g. 1: novice.
+	Labels map[int]string `yaml:"labels"`
+}
+
+// Range returns the lowest and highest scores of the scale, nil for an
+// unbounded side.
+func (s DimensionScale) Range() (*int, *int) {
+	if s.Min != nil || s.Max != nil {
+		return s.Min, s.Max
+	}
+
+	levels := s.Allowed
+	if len(levels) == 0 {
+		for level := range s.Labels {
+			levels = append(levels, level)
+		}
+	}
+	if len(levels) == 0 {
+		return nil, nil
+	}
+
+	min, max := levels[0], levels[0]
+	for _, level := range levels[1:] {
+		if level < min {
+			min = level
+		}
+		if level > max {
+			max = level
+		}
+	}
+	return &min, &max
+}
+
+// FormatRange formats the range of the scale, e.g. 1 to 5 or at least 0.
+func (s DimensionScale) FormatRange() string {
+	min, max := s.Range()
+	switch {
+	case min != nil && max != nil:
+		return fmt.Sprintf("%d to %d", *min, *max)
+	case min != nil:
+		return fmt.Sprintf("at least %d", *min)
+	case max != nil:
+		return fmt.Sprintf("at most %d", *max)
+	}
+	return "unbounded"
+}
+
+// Label returns the label of the level of the score, empty when it has none.
+func (s *DimensionScale) Label(score int) string {
+	if s == nil {
+		return ""
+	}
+	return s.Labels[score]
+}
+
 // EvaluationRecord ...
 type EvaluationRecord[E any] struct {
-	ID           string                `yaml:"id"`
-	Measurements []StandardMeasurement `yaml:"measurements"`
+	ID           string `yaml:"id"`
+	Measurements []E    `yaml:"measurements"`
 }
 
-// StandardMeasurement ...
-type StandardMeasurement struct {
+// Measurement is the measurement of a dimension of an evaluation record. Which
+// of its values is set depends on the kind of measurement the dimension declares.
+type Measurement struct {
 	Dimension string `yaml:"dimension"`
 	Score     *int   `yaml:"score"`
 	Remarks   string `yaml:"remarks"`
 	Wins      string `yaml:"wins"`
 	Mistakes  string `yaml:"mistakes"`
 	Meta 			string `yaml:"meta"`
+
+	RubricSelection `yaml:",inline"`
+	Checklist       `yaml:",inline"`
+	PassFail        `yaml:",inline"`
+
+	// Fields holds the fields of the measurement kinds registered outside of
+	// this package, keyed by name.
+	Fields map[string]interface{} `yaml:",inline"`
 }
+
+// StandardMeasurement is the former name of Measurement, kept for existing importers.
+type StandardMeasurement = Measurement
@@ -0,0 +1,103 @@
+package pkg
+
+import "fmt"
+
+const (
+	// MeasurementStandard is a numeric score, bounded by the scale of the dimension.
+	MeasurementStandard = "standard"
+	// MeasurementRubric selects a level of the rubric of the dimension.
+	MeasurementRubric = "rubric"
+	// MeasurementChecklist marks each criterion of the dimension as met or not.
+	MeasurementChecklist = "checklist"
+	// MeasurementPassFail is a gate, either passed or failed.
+	MeasurementPassFail = "pass-fail"
+)
+
+// MeasurementValue is the decoded value of a measurement, whatever its kind.
+type MeasurementValue interface {
+	// ScaleScore returns the score of the value on the scale of its dimension,
+	// nil for the kinds that are not scored on a scale, so that only
+	// comparable scores are summarized and charted.
+	ScaleScore() *int
+	String() string
+}
+
+// StandardScore is the value of a standard measurement.
+type StandardScore struct {
+	Score *int
+}
+
+// RubricSelection is the value of a rubric measurement.
+type RubricSelection struct {
+	Level *int `yaml:"level,omitempty"`
+}
+
+// Checklist is the value of a checklist measurement.
+type Checklist struct {
+	Checklist map[string]bool `yaml:"checklist,omitempty"`
+}
+
+// PassFail is the value of a pass/fail measurement.
+type PassFail struct {
+	Passed *bool `yaml:"passed,omitempty"`
+}
+
+// ScaleScore implements MeasurementValue
+func (v StandardScore) ScaleScore() *int {
+	return v.Score
+}
+
+func (v StandardScore) String() string {
+	if v.Score == nil {
+		return "-"
+	}
+	return fmt.Sprint(*v.Score)
+}
+
+// ScaleScore implements MeasurementValue, rubric levels being the levels of
+// the scale of the dimension.
+func (v RubricSelection) ScaleScore() *int {
+	return v.Level
+}
+
+func (v RubricSelection) String() string {
+	if v.Level == nil {
+		return "-"
+	}
+	return fmt.Sprintf("level %d", *v.Level)
+}
+
+// ScaleScore implements MeasurementValue. Checklists are not scored.
+func (v Checklist) ScaleScore() *int {
+	return nil
+}
+
+// Met returns the number of criteria met.
+func (v Checklist) Met() int {
+	met := 0
+	for _, ok := range v.Checklist {
+		if ok {
+			met++
+		}
+	}
+	return met
+}
+
+func (v Checklist) String() string {
+	return fmt.Sprintf("%d of %d criteria met", v.Met(), len(v.Checklist))
+}
+
+// ScaleScore implements MeasurementValue. Gates are not scored.
+func (v PassFail) ScaleScore() *int {
+	return nil
+}
+
+func (v PassFail) String() string {
+	switch {
+	case v.Passed == nil:
+		return "-"
+	case *v.Passed:
+		return "passed"
+	}
+	return "failed"
+}
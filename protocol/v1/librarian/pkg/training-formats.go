@@ -0,0 +1,112 @@
+package pkg
+
+import "time"
+
+const (
+	// FormatDSU is the meta.format.type of daily stand-up training files.
+	FormatDSU = "dsu"
+	// FormatRetrospective is the meta.format.type of weekly retrospective training files.
+	FormatRetrospective = "retrospective"
+	// FormatLearningLog is the meta.format.type of learning log training files.
+	FormatLearningLog = "learning_log"
+	// FormatPairingSession is the meta.format.type of pairing session training files.
+	FormatPairingSession = "pairing_session"
+)
+
+// TrainingEntry is an entry of a training file, which evaluations reference by ID.
+type TrainingEntry interface {
+	EntryID() string
+	EntryTags() []string
+}
+
+// DatedEntry is a training entry whose datetime is parsed once it is read,
+// with the datetime parser of its repository.
+type DatedEntry interface {
+	ParseDatetime(parser *DatetimeParser) error
+}
+
+// RetrospectiveEntry is a weekly retrospective.
+type RetrospectiveEntry struct {
+	ID          string    `yaml:"id"`
+	DatetimeRaw string    `yaml:"datetime"`
+	Datetime    time.Time `yaml:"datetime_value"`
+	Remarks     string    `yaml:"remarks"`
+	WentWell    string    `yaml:"went_well"`
+	ToImprove   string    `yaml:"to_improve"`
+	Actions     string    `yaml:"actions"`
+	Tags        []string  `yaml:"tags"`
+}
+
+// LearningLogEntry records time spent learning from a resource.
+type LearningLogEntry struct {
+	ID          string    `yaml:"id"`
+	DatetimeRaw string    `yaml:"datetime"`
+	Datetime    time.Time `yaml:"datetime_value"`
+	Remarks     string    `yaml:"remarks"`
+	// Resource is the book, course, article or video learned from.
+	Resource string `yaml:"resource"`
+	// TimeSpent is a duration such as 45m or 1h30m.
+	TimeSpent string   `yaml:"time_spent"`
+	Takeaways string   `yaml:"takeaways"`
+	Tags      []string `yaml:"tags"`
+}
+
+// PairingSessionEntry records a pairing session with another developer.
+type PairingSessionEntry struct {
+	ID          string    `yaml:"id"`
+	DatetimeRaw string    `yaml:"datetime"`
+	Datetime    time.Time `yaml:"datetime_value"`
+	Remarks     string    `yaml:"remarks"`
+	// Partners lists the people paired with.
+	Partners []string `yaml:"partners"`
+	Topic    string   `yaml:"topic"`
+	// Duration is a duration such as 45m or 1h30m.
+	Duration  string   `yaml:"duration"`
+	Learnings string   `yaml:"learnings"`
+	Tags      []string `yaml:"tags"`
+}
+
+// EntryID implements TrainingEntry
+func (r DSUReport) EntryID() string { return r.ID }
+
+// EntryTags implements TrainingEntry
+func (r DSUReport) EntryTags() []string { return r.Tags }
+
+// EntryID implements TrainingEntry
+func (r RetrospectiveEntry) EntryID() string { return r.ID }
+
+// EntryTags implements TrainingEntry
+func (r RetrospectiveEntry) EntryTags() []string { return r.Tags }
+
+// EntryID implements TrainingEntry
+func (r LearningLogEntry) EntryID() string { return r.ID }
+
+// EntryTags implements TrainingEntry
+func (r LearningLogEntry) EntryTags() []string { return r.Tags }
+
+// EntryID implements TrainingEntry
+func (r PairingSessionEntry) EntryID() string { return r.ID }
+
+// EntryTags implements TrainingEntry
+func (r PairingSessionEntry) EntryTags() []string { return r.Tags }
+
+// ParseDatetime implements DatedEntry
+func (r *RetrospectiveEntry) ParseDatetime(parser *DatetimeParser) error {
+	dateTime, err := parser.ParseEntry(r.ID, r.DatetimeRaw)
+	r.Datetime = dateTime
+	return err
+}
+
+// ParseDatetime implements DatedEntry
+func (r *LearningLogEntry) ParseDatetime(parser *DatetimeParser) error {
+	dateTime, err := parser.ParseEntry(r.ID, r.DatetimeRaw)
+	r.Datetime = dateTime
+	return err
+}
+
+// ParseDatetime implements DatedEntry
+func (r *PairingSessionEntry) ParseDatetime(parser *DatetimeParser) error {
+	dateTime, err := parser.ParseEntry(r.ID, r.DatetimeRaw)
+	r.Datetime = dateTime
+	return err
+}
@@ -15,6 +15,10 @@ type TrainingDefinition[E any] struct {
 					Type        string `yaml:"type"`
 					Version     string `yaml:"version"`
 					Definition  string `yaml:"definition"`
+					// Fields declares the custom fields of the entries.
+					Fields      []FieldDefinition `yaml:"fields"`
+					// Schema is the path, from the repository root, of a file declaring shared custom fields.
+					Schema      string `yaml:"schema"`
 			} `yaml:"format"`
 
 			Tags []string `yaml:"tags"`
@@ -0,0 +1,50 @@
+package pkg
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// DSUNamespace is the namespace of name-based DSU UUIDs: the version 5 UUID of
+// the URL https://protocol.tome.gg/formats/dsu/0.1.0.
+const DSUNamespace = "9d6f3322-a1b9-5dac-917c-f187e6a157cf"
+
+// NewUUID generates a random (version 4) UUID.
+func NewUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	return formatUUID(b), nil
+}
+
+// NewNameUUID generates a name-based (version 5) UUID, which is always the same
+// for the same namespace and name.
+func NewNameUUID(namespace string, name string) (string, error) {
+	ns, err := hex.DecodeString(strings.ReplaceAll(namespace, "-", ""))
+	if err != nil || len(ns) != 16 {
+		return "", fmt.Errorf("invalid namespace UUID %q", namespace)
+	}
+
+	hash := sha1.New()
+	hash.Write(ns)
+	hash.Write([]byte(name))
+
+	var b [16]byte
+	copy(b[:], hash.Sum(nil))
+	b[6] = (b[6] & 0x0f) | 0x50 // version 5
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	return formatUUID(b), nil
+}
+
+func formatUUID(b [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -16,6 +16,9 @@ type (
 		Files []*File
 		FileWeights []int
 		Metadata map[string]interface{}
+
+		// Config defines the repository configuration read from tome.yaml.
+		Config *TomeConfig
 	}
 )
 
@@ -50,6 +53,7 @@ func NewValidationPlan(ds []*Directory, fs []*File) *ValidationPlan {
 		DirectoryWeights: []int{},
 		Files: dedupedFiles,
 		FileWeights: []int{},
+		Config: &TomeConfig{},
 		Metadata: map[string]interface{}{
 			"registeredTraining": []string{},
 			"validTraining": []string{},
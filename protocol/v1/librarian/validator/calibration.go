@@ -0,0 +1,269 @@
+package validator
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CalibrationTrend is whether two evaluators agree more or less over time.
+type CalibrationTrend string
+
+const (
+	// CalibrationConverging means the gap between the scores is closing.
+	CalibrationConverging CalibrationTrend = "converging"
+	// CalibrationDiverging means the gap between the scores is widening.
+	CalibrationDiverging CalibrationTrend = "diverging"
+	// CalibrationSteady means the gap is steady, or the pairs are too few to tell.
+	CalibrationSteady CalibrationTrend = "steady"
+)
+
+// DefaultDisagreements is the number of biggest disagreements a calibration lists.
+const DefaultDisagreements = 3
+
+type (
+	// CalibrationQuery selects the measurements to calibrate.
+	CalibrationQuery struct {
+		// Dimension restricts the calibration to a dimension name, if set.
+		Dimension string
+		// Evaluator restricts the calibration to the pairs with this evaluator, if set.
+		Evaluator string
+		Since     time.Time
+		Until     time.Time
+		// Disagreements is the number of biggest disagreements to list.
+		Disagreements int
+	}
+
+	// Calibration compares the scores two evaluators gave the same training
+	// entries on a dimension. Differences are the second evaluator's score
+	// minus the first's; self evaluations always come first.
+	Calibration struct {
+		Dimension string `json:"dimension"`
+		First     string `json:"first"`
+		Second    string `json:"second"`
+		// Pairs lists the paired measurements, sorted by date.
+		Pairs []CalibrationPair `json:"pairs"`
+		// MeanDifference is the average difference: positive when the second
+		// evaluator scores higher.
+		MeanDifference float64 `json:"mean_difference"`
+		// MeanAbsoluteDifference is the average gap between the scores.
+		MeanAbsoluteDifference float64 `json:"mean_absolute_difference"`
+		// Disagreements lists the pairs with the biggest gaps, biggest first.
+		Disagreements []CalibrationPair `json:"disagreements"`
+		Trend         CalibrationTrend  `json:"trend"`
+	}
+
+	// CalibrationPair is a training entry scored on the same dimension by two evaluators.
+	CalibrationPair struct {
+		ID         string    `json:"id"`
+		Date       time.Time `json:"date"`
+		First      int       `json:"first"`
+		Second     int       `json:"second"`
+		Difference int       `json:"difference"`
+		// FirstRemarks and SecondRemarks are the remarks of each evaluator.
+		FirstRemarks  string `json:"first_remarks,omitempty"`
+		SecondRemarks string `json:"second_remarks,omitempty"`
+	}
+)
+
+// calibrationScore is a score of an evaluator on a training entry and dimension.
+type calibrationScore struct {
+	score   int
+	remarks string
+}
+
+// calibrationEvaluator gathers the evaluation files of an evaluator.
+type calibrationEvaluator struct {
+	name  string
+	self  bool
+	files []EvaluationFile
+	// scores maps each dimension to the first score per training ID.
+	scores map[string]map[string]calibrationScore
+}
+
+// evaluatedBy returns true if any file of the evaluator was written by who.
+func (e calibrationEvaluator) evaluatedBy(who string) bool {
+	for _, file := range e.files {
+		if file.EvaluatedBy(who) {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildCalibrations groups the evaluation files by evaluator, pairs the
+// measurements of every two evaluators by training ID and dimension, and
+// returns a calibration per pair of evaluators and dimension with at least one
+// paired measurement. Training IDs are dated by their DSU entries, if any;
+// meta evaluations of the mentor are left out.
+func BuildCalibrations(dsuFiles []DSUFile, evaluationFiles []EvaluationFile, query CalibrationQuery) []Calibration {
+	top := query.Disagreements
+	if top <= 0 {
+		top = DefaultDisagreements
+	}
+
+	dates := map[string]time.Time{}
+	for _, file := range dsuFiles {
+		for _, entry := range file.Definition.Content {
+			if !entry.Datetime.IsZero() {
+				dates[entry.ID] = entry.Datetime
+			}
+		}
+	}
+
+	files := []EvaluationFile{}
+	for _, file := range evaluationFiles {
+		if !file.IsMeta() {
+			files = append(files, file)
+		}
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Filepath < files[j].Filepath
+	})
+
+	evaluators := []*calibrationEvaluator{}
+	byID := map[string]*calibrationEvaluator{}
+	for _, file := range files {
+		evaluator, ok := byID[file.EvaluatorID()]
+		if !ok {
+			evaluator = &calibrationEvaluator{name: file.EvaluatorName(), scores: map[string]map[string]calibrationScore{}}
+			byID[file.EvaluatorID()] = evaluator
+			evaluators = append(evaluators, evaluator)
+		}
+		evaluator.self = evaluator.self || file.IsSelf()
+		evaluator.files = append(evaluator.files, file)
+	}
+	sort.SliceStable(evaluators, func(i, j int) bool {
+		if evaluators[i].self != evaluators[j].self {
+			return evaluators[i].self
+		}
+		return evaluators[i].name < evaluators[j].name
+	})
+
+	for _, evaluator := range evaluators {
+		for id, measurements := range collectMeasurements(evaluator.files, false) {
+			date, dated := dates[id]
+			if (!query.Since.IsZero() || !query.Until.IsZero()) && (!dated || !InDateRange(date, query.Since, query.Until)) {
+				continue
+			}
+			for _, measurement := range measurements {
+				if measurement.Score == nil {
+					continue
+				}
+				if query.Dimension != "" && !strings.EqualFold(measurement.Dimension, query.Dimension) {
+					continue
+				}
+				if evaluator.scores[measurement.Dimension] == nil {
+					evaluator.scores[measurement.Dimension] = map[string]calibrationScore{}
+				}
+				if _, ok := evaluator.scores[measurement.Dimension][id]; !ok {
+					evaluator.scores[measurement.Dimension][id] = calibrationScore{score: *measurement.Score, remarks: strings.TrimSpace(measurement.Remarks)}
+				}
+			}
+		}
+	}
+
+	calibrations := []Calibration{}
+	for i, first := range evaluators {
+		for _, second := range evaluators[i+1:] {
+			if query.Evaluator != "" && !first.evaluatedBy(query.Evaluator) && !second.evaluatedBy(query.Evaluator) {
+				continue
+			}
+			for dimension, firstScores := range first.scores {
+				pairs := []CalibrationPair{}
+				for id, firstScore := range firstScores {
+					secondScore, ok := second.scores[dimension][id]
+					if !ok {
+						continue
+					}
+					pairs = append(pairs, CalibrationPair{
+						ID:            id,
+						Date:          dates[id],
+						First:         firstScore.score,
+						Second:        secondScore.score,
+						Difference:    secondScore.score - firstScore.score,
+						FirstRemarks:  firstScore.remarks,
+						SecondRemarks: secondScore.remarks,
+					})
+				}
+				if len(pairs) == 0 {
+					continue
+				}
+				calibrations = append(calibrations, calibrate(dimension, first.name, second.name, pairs, top))
+			}
+		}
+	}
+
+	sort.SliceStable(calibrations, func(i, j int) bool {
+		return calibrations[i].Dimension < calibrations[j].Dimension
+	})
+	return calibrations
+}
+
+// calibrate summarizes the pairs of two evaluators on a dimension.
+func calibrate(dimension string, first string, second string, pairs []CalibrationPair, top int) Calibration {
+	sort.Slice(pairs, func(i, j int) bool {
+		if !pairs[i].Date.Equal(pairs[j].Date) {
+			return pairs[i].Date.Before(pairs[j].Date)
+		}
+		return pairs[i].ID < pairs[j].ID
+	})
+
+	sum, sumAbsolute := 0, 0
+	// The trend of the gap is fitted over the dated pairs, in weeks.
+	weeks, gaps := []float64{}, []float64{}
+	var start time.Time
+	for _, pair := range pairs {
+		sum += pair.Difference
+		gap := math.Abs(float64(pair.Difference))
+		sumAbsolute += int(gap)
+		if pair.Date.IsZero() {
+			continue
+		}
+		if start.IsZero() {
+			start = pair.Date
+		}
+		weeks = append(weeks, float64(pair.Date.Sub(start))/float64(week))
+		gaps = append(gaps, gap)
+	}
+
+	disagreements := append([]CalibrationPair{}, pairs...)
+	sort.SliceStable(disagreements, func(i, j int) bool {
+		return math.Abs(float64(disagreements[i].Difference)) > math.Abs(float64(disagreements[j].Difference))
+	})
+	for len(disagreements) > 0 && disagreements[len(disagreements)-1].Difference == 0 {
+		disagreements = disagreements[:len(disagreements)-1]
+	}
+	if len(disagreements) > top {
+		disagreements = disagreements[:top]
+	}
+
+	trend := CalibrationSteady
+	switch trendOf(fitSlope(weeks, gaps)) {
+	case TrendDown:
+		trend = CalibrationConverging
+	case TrendUp:
+		trend = CalibrationDiverging
+	}
+
+	return Calibration{
+		Dimension:              dimension,
+		First:                  first,
+		Second:                 second,
+		Pairs:                  pairs,
+		MeanDifference:         float64(sum) / float64(len(pairs)),
+		MeanAbsoluteDifference: float64(sumAbsolute) / float64(len(pairs)),
+		Disagreements:          disagreements,
+		Trend:                  trend,
+	}
+}
+
+// Gaps returns the absolute differences of the pairs, in order.
+func (c Calibration) Gaps() []float64 {
+	gaps := make([]float64, len(c.Pairs))
+	for i, pair := range c.Pairs {
+		gaps[i] = math.Abs(float64(pair.Difference))
+	}
+	return gaps
+}
@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+func mockScoredFile(path string, evaluator string, scores map[string]int) EvaluationFile {
+	file := EvaluationFile{Filepath: path}
+	if evaluator != "" {
+		file.Definition.Meta.Evaluator = &pkg.Evaluator{Name: evaluator}
+	}
+	file.Definition.Meta.Dimensions = []pkg.DimensionDeclaration{{Alias: "f", Name: "focus", Version: "0.1.0"}}
+	for id, score := range scores {
+		score := score
+		file.Definition.Evaluations = append(file.Definition.Evaluations, pkg.EvaluationRecord[pkg.Measurement]{
+			ID:           id,
+			Measurements: []pkg.Measurement{{Dimension: "f", Score: &score, Remarks: evaluator + " on " + id}},
+		})
+	}
+	return file
+}
+
+func TestBuildCalibrations(t *testing.T) {
+	dsuFile := DSUFile{Filepath: "training/dsu-reports.yaml"}
+	dsuFile.Definition.Content = mockDatedEntries("2024-07-01", "2024-07-02", "2024-07-03", "2024-07-04")
+
+	mentor := mockScoredFile("evaluations/mentor.yaml", "Ada", map[string]int{
+		"2024-07-01": 5, "2024-07-02": 4, "2024-07-03": 3, "2024-07-04": 3,
+	})
+	self := mockScoredFile("evaluations/self.yaml", "", map[string]int{
+		"2024-07-01": 1, "2024-07-02": 2, "2024-07-04": 3,
+	})
+
+	calibrations := BuildCalibrations([]DSUFile{dsuFile}, []EvaluationFile{mentor, self}, CalibrationQuery{Disagreements: 1})
+	if len(calibrations) != 1 {
+		t.Fatalf("Expected a single calibration, but got %+v", calibrations)
+	}
+
+	calibration := calibrations[0]
+	if calibration.First != SelfEvaluator || calibration.Second != "Ada" {
+		t.Errorf("Expected the self evaluation first, but got %s vs %s", calibration.First, calibration.Second)
+	}
+	if len(calibration.Pairs) != 3 {
+		t.Fatalf("Expected 3 paired scores, but got %d", len(calibration.Pairs))
+	}
+	if calibration.MeanDifference != 2 || calibration.MeanAbsoluteDifference != 2 {
+		t.Errorf("Expected a mean difference of 2, but got %.2f and %.2f", calibration.MeanDifference, calibration.MeanAbsoluteDifference)
+	}
+	if len(calibration.Disagreements) != 1 || calibration.Disagreements[0].ID != "2024-07-01" || calibration.Disagreements[0].SecondRemarks != "Ada on 2024-07-01" {
+		t.Errorf("Expected the 2024-07-01 disagreement with its remarks, but got %+v", calibration.Disagreements)
+	}
+	if calibration.Trend != CalibrationConverging {
+		t.Errorf("Expected the gap to be converging, but got %s", calibration.Trend)
+	}
+
+	calibrations = BuildCalibrations([]DSUFile{dsuFile}, []EvaluationFile{mentor, self}, CalibrationQuery{Evaluator: "Grace"})
+	if len(calibrations) != 0 {
+		t.Errorf("Expected no calibration for an unknown evaluator, but got %+v", calibrations)
+	}
+}
+
+func TestBuildCalibrationsByEvaluator(t *testing.T) {
+	dsuFile := DSUFile{Filepath: "training/dsu-reports.yaml"}
+	dsuFile.Definition.Content = mockDatedEntries("2024-07-01", "2024-07-02", "2024-07-03", "2024-07-31")
+
+	// Ada's evaluations are split across two files, which are not compared
+	// with each other but pooled against the self evaluations.
+	ada2024 := mockScoredFile("evaluations/ada-2024.yaml", "Ada", map[string]int{"2024-07-01": 5, "2024-07-02": 5})
+	ada := mockScoredFile("evaluations/ada.yaml", "ada", map[string]int{"2024-07-03": 2, "2024-07-31": 5})
+	for _, file := range []*EvaluationFile{&ada2024, &ada} {
+		file.Definition.Meta.Evaluator.Socials.Email = "ada@example.com"
+	}
+	// By index the gap closes then widens back, but it stays wide for a
+	// month after closing only for a day.
+	self := mockScoredFile("evaluations/self.yaml", "", map[string]int{
+		"2024-07-01": 1, "2024-07-02": 1, "2024-07-03": 2, "2024-07-31": 1,
+	})
+
+	calibrations := BuildCalibrations([]DSUFile{dsuFile}, []EvaluationFile{ada2024, ada, self}, CalibrationQuery{})
+	if len(calibrations) != 1 {
+		t.Fatalf("Expected a single calibration of self against Ada, but got %+v", calibrations)
+	}
+
+	calibration := calibrations[0]
+	if calibration.First != SelfEvaluator || calibration.Second != "Ada" || len(calibration.Pairs) != 4 {
+		t.Errorf("Expected 4 pairs of self against Ada, but got %s vs %s with %d pairs", calibration.First, calibration.Second, len(calibration.Pairs))
+	}
+	if calibration.Trend != CalibrationDiverging {
+		t.Errorf("Expected the gap to be diverging over time, but got %s", calibration.Trend)
+	}
+}
@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+// ValidateScale checks that the scale of a dimension is consistent: a non-empty
+// range, with the allowed values and labelled levels inside of it.
+func ValidateScale(dimension string, scale pkg.DimensionScale) error {
+	min, max := scale.Range()
+	if min != nil && max != nil && *min > *max {
+		return ErrInvalidScale(dimension, fmt.Sprintf("min %d is greater than max %d", *min, *max))
+	}
+	if min == nil && max == nil {
+		return ErrInvalidScale(dimension, "missing min or max, allowed values or labels")
+	}
+
+	if scale.Step < 0 {
+		return ErrInvalidScale(dimension, fmt.Sprintf("step %d is negative", scale.Step))
+	}
+
+	if scale.Step > 0 && min == nil {
+		return ErrInvalidScale(dimension, "step needs a min to start from")
+	}
+
+	if scale.Step > 0 && len(scale.Allowed) > 0 {
+		return ErrInvalidScale(dimension, "step and allowed cannot be used together")
+	}
+
+	for _, value := range scale.Allowed {
+		if !inRange(value, min, max) {
+			return ErrInvalidScale(dimension, fmt.Sprintf("allowed value %d is outside of %s", value, scale.FormatRange()))
+		}
+	}
+
+	for level := range scale.Labels {
+		if err := CheckScore(scale, level); err != nil {
+			return ErrInvalidScale(dimension, fmt.Sprintf("label of level %d: %s", level, err))
+		}
+	}
+
+	return nil
+}
+
+// CheckScore checks that the score is one of the levels of the scale.
+func CheckScore(scale pkg.DimensionScale, score int) error {
+	min, max := scale.Range()
+	if !inRange(score, min, max) {
+		return fmt.Errorf("%d is outside of %s", score, scale.FormatRange())
+	}
+
+	if scale.Step > 0 && min != nil && (score-*min)%scale.Step != 0 {
+		return fmt.Errorf("%d is not a step of %d from %d", score, scale.Step, *min)
+	}
+
+	if len(scale.Allowed) > 0 {
+		for _, value := range scale.Allowed {
+			if value == score {
+				return nil
+			}
+		}
+		return fmt.Errorf("%d is not one of %v", score, scale.Allowed)
+	}
+
+	return nil
+}
+
+// ScoreScale returns the scale bounding the scores of a dimension: its own
+// scale, else the levels of its rubric in the catalogue, else nil.
+func ScoreScale(dimension pkg.DimensionDeclaration, catalog *pkg.DimensionCatalog) *pkg.DimensionScale {
+	if dimension.Scale != nil {
+		return dimension.Scale
+	}
+	levels := RubricLevels(dimension, catalog)
+	if len(levels) == 0 {
+		return nil
+	}
+	return &pkg.DimensionScale{Labels: levels}
+}
+
+// inRange returns true if the value is within the bounds, nil bounds being unbounded.
+func inRange(value int, min *int, max *int) bool {
+	return (min == nil || value >= *min) && (max == nil || value <= *max)
+}
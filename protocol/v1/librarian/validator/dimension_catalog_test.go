@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+const mockCatalogDimension = `name: grit
+label: Grit
+description: Keeping at hard problems.
+definition: https://example.com/dimensions/grit
+versions:
+  - version: 0.1.0
+    rubric:
+      1: Gave up early.
+  - version: 0.2.0
+    changes: Clarified the rubric.
+    rubric:
+      1: Gave up at the first obstacle.
+      3: Kept going with help.
+`
+
+const mockCatalogEvaluationFile = `tomegg:
+  type: evaluations
+  version: 0.1.0
+  definition: https://protocol.tome.gg/evaluations/0.1.0
+
+meta:
+  dimensions:
+    - alias: g
+      name: grit
+      version: 0.2.0
+      definition: https://example.com/dimensions/grit/0.2.0
+    - alias: focus
+      name: focus
+      version: 0.1.0
+      definition: https://protocol.tome.gg/dimensions/focus/0.1.0
+
+evaluations:
+  - id: e1
+    measurements:
+      - dimension: g
+        score: 3
+`
+
+func TestEvaluationValidatorChecksCatalog(t *testing.T) {
+	root := t.TempDir()
+	writeMockFile(t, filepath.Join(root, "dimensions", "grit.yaml"), mockCatalogDimension)
+	path := filepath.Join(root, "evaluations", "self.yaml")
+
+	validate := func(content string) error {
+		writeMockFile(t, path, content)
+
+		config, err := pkg.LoadConfig(root)
+		if err != nil {
+			t.Fatalf("failed to load config: %s", err)
+		}
+		plan := pkg.NewValidationPlan(nil, nil)
+		plan.Config = config
+		plan.Metadata["registeredTraining"] = []string{"e1"}
+		plan.Metadata["validTraining"] = []string{"e1"}
+
+		return NewEvaluationValidator(plan).File(&pkg.File{Filepath: path})
+	}
+
+	if err := validate(mockCatalogEvaluationFile); err != nil {
+		t.Fatalf("Expected catalogued dimensions to be valid, but got %s", err)
+	}
+	if err := validate(strings.ReplaceAll(mockCatalogEvaluationFile, "0.2.0", "0.3.0")); err == nil {
+		t.Error("Expected an error for a dimension version missing from the catalogue")
+	}
+	if err := validate(strings.ReplaceAll(mockCatalogEvaluationFile, "grit", "stamina")); err == nil {
+		t.Error("Expected an error for a dimension missing from the catalogue")
+	}
+
+	// The embedded official catalogue is incomplete: official dimensions and
+	// versions missing from it are only warned about.
+	official := strings.ReplaceAll(mockCatalogEvaluationFile, "https://example.com/dimensions/grit", "https://protocol.tome.gg/dimensions/grit")
+	if err := validate(strings.ReplaceAll(official, "grit", "stamina")); err != nil {
+		t.Errorf("Expected an official dimension missing from the catalogue to be valid, but got %s", err)
+	}
+	newerFocus := strings.ReplaceAll(mockCatalogEvaluationFile, "version: 0.1.0\n      definition: https://protocol.tome.gg/dimensions/focus/0.1.0", "version: 0.9.0\n      definition: https://protocol.tome.gg/dimensions/focus/0.9.0")
+	if err := validate(newerFocus); err != nil {
+		t.Errorf("Expected an official version missing from the catalogue to be valid, but got %s", err)
+	}
+}
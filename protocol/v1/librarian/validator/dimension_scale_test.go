@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+func TestValidateScale(t *testing.T) {
+	valid := []pkg.DimensionScale{
+		{Min: bound(1), Max: bound(5), Labels: map[int]string{1: "novice", 5: "expert"}},
+		{Min: bound(0), Max: bound(10), Step: 2, Labels: map[int]string{4: "halfway"}},
+		{Min: bound(1), Max: bound(5), Allowed: []int{1, 3, 5}},
+		{Labels: map[int]string{1: "novice", 3: "competent", 5: "expert"}},
+		{Allowed: []int{2, 4}, Labels: map[int]string{4: "good"}},
+		{Min: bound(0)},
+		{Max: bound(10), Labels: map[int]string{-2: "behind"}},
+	}
+	for _, scale := range valid {
+		if err := ValidateScale("focus", scale); err != nil {
+			t.Errorf("Expected %+v to be valid, but got %s", scale, err)
+		}
+	}
+
+	invalid := []pkg.DimensionScale{
+		{Min: bound(5), Max: bound(1)},
+		{Min: bound(1), Max: bound(5), Step: -1},
+		{Min: bound(1), Max: bound(5), Step: 2, Allowed: []int{1, 3}},
+		{Min: bound(1), Max: bound(5), Allowed: []int{0}},
+		{Min: bound(1), Max: bound(5), Labels: map[int]string{6: "beyond"}},
+		{Min: bound(0), Max: bound(10), Step: 2, Labels: map[int]string{3: "odd"}},
+		{},
+		{Max: bound(10), Step: 2},
+		{Min: bound(1), Labels: map[int]string{0: "none"}},
+		{Allowed: []int{2, 4}, Labels: map[int]string{5: "beyond"}},
+	}
+	for _, scale := range invalid {
+		if err := ValidateScale("focus", scale); err == nil {
+			t.Errorf("Expected %+v to be invalid", scale)
+		}
+	}
+}
+
+func TestCheckScore(t *testing.T) {
+	cases := []struct {
+		scale pkg.DimensionScale
+		score int
+		valid bool
+	}{
+		{pkg.DimensionScale{Min: bound(1), Max: bound(5)}, 1, true},
+		{pkg.DimensionScale{Min: bound(1), Max: bound(5)}, 5, true},
+		{pkg.DimensionScale{Min: bound(1), Max: bound(5)}, 47, false},
+		{pkg.DimensionScale{Min: bound(1), Max: bound(5)}, -3, false},
+		{pkg.DimensionScale{Min: bound(0), Max: bound(10), Step: 5}, 5, true},
+		{pkg.DimensionScale{Min: bound(0), Max: bound(10), Step: 5}, 7, false},
+		{pkg.DimensionScale{Min: bound(1), Max: bound(5), Allowed: []int{1, 3, 5}}, 3, true},
+		{pkg.DimensionScale{Min: bound(1), Max: bound(5), Allowed: []int{1, 3, 5}}, 2, false},
+		{pkg.DimensionScale{Labels: map[int]string{1: "novice", 5: "expert"}}, 3, true},
+		{pkg.DimensionScale{Labels: map[int]string{1: "novice", 5: "expert"}}, 6, false},
+		{pkg.DimensionScale{Allowed: []int{2, 4}}, 4, true},
+		{pkg.DimensionScale{Allowed: []int{2, 4}}, 3, false},
+		{pkg.DimensionScale{Min: bound(1)}, 1000, true},
+		{pkg.DimensionScale{Min: bound(1)}, 0, false},
+		{pkg.DimensionScale{Max: bound(5)}, -1000, true},
+		{pkg.DimensionScale{Max: bound(5)}, 6, false},
+	}
+	for _, tc := range cases {
+		if err := CheckScore(tc.scale, tc.score); (err == nil) != tc.valid {
+			t.Errorf("CheckScore(%+v, %d): expected valid to be %t, but got %v", tc.scale, tc.score, tc.valid, err)
+		}
+	}
+}
+
+func TestStandardScoresFallBackToTheRubric(t *testing.T) {
+	catalog := mockDimensionCatalog(t)
+	file := EvaluationFile{Filepath: "evaluations/mentor.yaml"}
+	file.Definition.Meta.Dimensions = []pkg.DimensionDeclaration{
+		{Alias: "grit", Name: "grit", Version: "0.1.0"},
+		{Alias: "setup", Name: "onboarding", Version: "0.1.0"},
+	}
+
+	cases := []struct {
+		dimension string
+		score     int
+		valid     bool
+	}{
+		{"grit", 5, true},
+		{"grit", 9, false},
+		{"setup", 9, true},
+	}
+	for _, tc := range cases {
+		record := pkg.EvaluationRecord[pkg.Measurement]{ID: "e1", Measurements: []pkg.Measurement{
+			{Dimension: tc.dimension, Score: bound(tc.score)},
+		}}
+		if err := ValidateMeasurements(file, record, catalog); (err == nil) != tc.valid {
+			t.Errorf("%s score %d: expected valid to be %t, but got %v", tc.dimension, tc.score, tc.valid, err)
+		}
+	}
+}
+
+// bound returns a pointer to a bound of a scale.
+func bound(value int) *int {
+	return &value
+}
@@ -0,0 +1,100 @@
+package validator
+
+import (
+	"sort"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+// DefaultBlockerSimilarity is the similarity above which blockers reported on
+// consecutive DSUs are considered the same blocker.
+const DefaultBlockerSimilarity = 0.5
+
+// Blocker is a blocker tracked across consecutive DSU entries.
+type Blocker struct {
+	// Text is the latest wording of the blocker.
+	Text string `json:"text" yaml:"text"`
+
+	FirstSeen   time.Time `json:"first_seen" yaml:"first_seen"`
+	FirstSeenID string    `json:"first_seen_id" yaml:"first_seen_id"`
+	LastSeen    time.Time `json:"last_seen" yaml:"last_seen"`
+	LastSeenID  string    `json:"last_seen_id" yaml:"last_seen_id"`
+
+	// Occurrences is the number of consecutive DSUs reporting the blocker.
+	Occurrences int `json:"occurrences" yaml:"occurrences"`
+	// Open is true if the blocker is reported in the latest DSU.
+	Open bool `json:"open" yaml:"open"`
+}
+
+// Days returns the number of days from the first report of the blocker until
+// the given date.
+func (b Blocker) Days(until time.Time) int {
+	return int(pkg.DateOf(until).Sub(pkg.DateOf(b.FirstSeen)).Hours() / 24)
+}
+
+// TrackBlockers splits the blockers of every DSU entry into list items,
+// ignoring placeholders such as "None", and follows each blocker across
+// consecutive entries by fuzzy text similarity. Blockers are returned in the
+// order they were first reported.
+func TrackBlockers(entries []pkg.DSUReport, similarity float64) []Blocker {
+	sorted := make([]pkg.DSUReport, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Datetime.Before(sorted[j].Datetime)
+	})
+
+	blockers := []*Blocker{}
+	open := []*Blocker{}
+
+	for _, entry := range sorted {
+		stillOpen := []*Blocker{}
+		matched := map[*Blocker]bool{}
+
+		for _, item := range SplitListItems(entry.Blockers) {
+			if IsBlockerPlaceholder(item) {
+				continue
+			}
+
+			var best *Blocker
+			bestScore := similarity
+			for _, blocker := range open {
+				if matched[blocker] {
+					continue
+				}
+				if score := TextSimilarity(blocker.Text, item); score >= bestScore {
+					best = blocker
+					bestScore = score
+				}
+			}
+
+			if best == nil {
+				best = &Blocker{
+					FirstSeen:   entry.Datetime,
+					FirstSeenID: entry.ID,
+				}
+				blockers = append(blockers, best)
+			}
+
+			best.Text = item
+			best.LastSeen = entry.Datetime
+			best.LastSeenID = entry.ID
+			best.Occurrences++
+			matched[best] = true
+			stillOpen = append(stillOpen, best)
+		}
+
+		open = stillOpen
+	}
+
+	for _, blocker := range open {
+		blocker.Open = true
+	}
+
+	result := make([]Blocker, len(blockers))
+	for i, blocker := range blockers {
+		result[i] = *blocker
+	}
+
+	return result
+}
@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"sort"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+// DefaultContinuitySimilarity is the similarity above which a planned item
+// and a reported item are considered the same task.
+const DefaultContinuitySimilarity = 0.5
+
+type (
+	// ContinuityReport compares what was planned in doing_today with what the
+	// next DSU reported in done_yesterday.
+	ContinuityReport struct {
+		// Transitions lists every pair of consecutive DSUs.
+		Transitions []ContinuityTransition `json:"transitions" yaml:"transitions"`
+		// Planned and Completed count the planned items and those reported done.
+		Planned   int `json:"planned" yaml:"planned"`
+		Completed int `json:"completed" yaml:"completed"`
+	}
+
+	// ContinuityTransition compares a DSU with the DSU that follows it.
+	ContinuityTransition struct {
+		FromID   string    `json:"from_id" yaml:"from_id"`
+		FromDate time.Time `json:"from_date" yaml:"from_date"`
+		ToID     string    `json:"to_id" yaml:"to_id"`
+		ToDate   time.Time `json:"to_date" yaml:"to_date"`
+
+		// Dropped lists the items planned in doing_today that were never reported done.
+		Dropped []string `json:"dropped" yaml:"dropped"`
+		// Unplanned lists the items reported in done_yesterday that were never planned.
+		Unplanned []string `json:"unplanned" yaml:"unplanned"`
+		// Completed counts the planned items reported done.
+		Completed int `json:"completed" yaml:"completed"`
+	}
+)
+
+// Accuracy returns the share of planned items reported done, from 0 to 1.
+func (r ContinuityReport) Accuracy() float64 {
+	if r.Planned == 0 {
+		return 1
+	}
+	return float64(r.Completed) / float64(r.Planned)
+}
+
+// CheckContinuity matches the doing_today items of every DSU entry against the
+// done_yesterday items of the next entry by fuzzy text similarity.
+func CheckContinuity(entries []pkg.DSUReport, similarity float64) ContinuityReport {
+	sorted := make([]pkg.DSUReport, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Datetime.Before(sorted[j].Datetime)
+	})
+
+	report := ContinuityReport{Transitions: []ContinuityTransition{}}
+
+	for i := 1; i < len(sorted); i++ {
+		from, to := sorted[i-1], sorted[i]
+		planned := SplitListItems(from.DoingToday)
+		done := SplitListItems(to.DoneYesterday)
+
+		transition := ContinuityTransition{
+			FromID:    from.ID,
+			FromDate:  from.Datetime,
+			ToID:      to.ID,
+			ToDate:    to.Datetime,
+			Dropped:   []string{},
+			Unplanned: []string{},
+		}
+
+		matched := make([]bool, len(done))
+		for _, item := range planned {
+			best := -1
+			bestScore := similarity
+			for j, candidate := range done {
+				if matched[j] {
+					continue
+				}
+				if score := TextSimilarity(item, candidate); score >= bestScore {
+					best = j
+					bestScore = score
+				}
+			}
+
+			if best == -1 {
+				transition.Dropped = append(transition.Dropped, item)
+				continue
+			}
+			matched[best] = true
+			transition.Completed++
+		}
+
+		for j, item := range done {
+			if !matched[j] {
+				transition.Unplanned = append(transition.Unplanned, item)
+			}
+		}
+
+		report.Planned += len(planned)
+		report.Completed += transition.Completed
+		report.Transitions = append(report.Transitions, transition)
+	}
+
+	return report
+}
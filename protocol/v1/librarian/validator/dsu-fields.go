@@ -0,0 +1,164 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	"gopkg.in/yaml.v2"
+)
+
+// ResolveFieldDefinitions returns the custom fields declared by a training
+// file: those of its shared schema file, if any, overridden by its own
+// meta.format.fields. The schema path is read from the repository root.
+func ResolveFieldDefinitions(root string, fields []pkg.FieldDefinition, schema string) ([]pkg.FieldDefinition, error) {
+	resolved := []pkg.FieldDefinition{}
+
+	if schema != "" {
+		path := schema
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, schema)
+		}
+
+		fileBytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read field schema %s: %s", schema, err)
+		}
+
+		shared := pkg.FieldSchema{}
+		if err := yaml.Unmarshal(fileBytes, &shared); err != nil {
+			return nil, fmt.Errorf("failed to parse field schema %s: %s", schema, err)
+		}
+		resolved = append(resolved, shared.Fields...)
+	}
+
+	for _, field := range fields {
+		overridden := false
+		for i := range resolved {
+			if resolved[i].Name == field.Name {
+				resolved[i] = field
+				overridden = true
+			}
+		}
+		if !overridden {
+			resolved = append(resolved, field)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, field := range resolved {
+		if err := validateFieldDefinition(field); err != nil {
+			return nil, err
+		}
+		if seen[field.Name] {
+			return nil, ErrInvalidFieldDefinition(field.Name, "declared more than once")
+		}
+		seen[field.Name] = true
+	}
+
+	return resolved, nil
+}
+
+func validateFieldDefinition(field pkg.FieldDefinition) error {
+	if field.Name == "" {
+		return ErrInvalidFieldDefinition(field.Name, "missing name")
+	}
+	for _, builtin := range pkg.DSUBuiltinFields {
+		if field.Name == builtin {
+			return ErrInvalidFieldDefinition(field.Name, "redefines a DSU field")
+		}
+	}
+
+	switch field.Type {
+	case pkg.FieldTypeString, pkg.FieldTypeInt, pkg.FieldTypeList:
+	case pkg.FieldTypeEnum:
+		if len(field.Values) == 0 {
+			return ErrInvalidFieldDefinition(field.Name, "enum without values")
+		}
+	default:
+		return ErrInvalidFieldDefinition(field.Name, fmt.Sprintf("unsupported type %q, expected string, int, enum or list", field.Type))
+	}
+
+	return nil
+}
+
+// ValidateDSUFields checks the custom fields of a DSU entry against their
+// declarations. It returns the names of the custom fields that are not declared.
+func ValidateDSUFields(fields []pkg.FieldDefinition, e pkg.DSUReport) ([]string, error) {
+	declared := map[string]bool{}
+
+	for _, field := range fields {
+		declared[field.Name] = true
+
+		value, ok := e.Fields[field.Name]
+		if !ok || value == nil {
+			if field.Required {
+				return nil, ErrRequiredField(e.ID, field.Name)
+			}
+			continue
+		}
+
+		if err := validateFieldValue(field, value); err != nil {
+			return nil, ErrInvalidField(e.ID, field.Name, err.Error())
+		}
+	}
+
+	undeclared := []string{}
+	for _, name := range e.CustomFieldNames() {
+		if !declared[name] {
+			undeclared = append(undeclared, name)
+		}
+	}
+
+	return undeclared, nil
+}
+
+func validateFieldValue(field pkg.FieldDefinition, value interface{}) error {
+	switch field.Type {
+	case pkg.FieldTypeString:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("expected text, but got %v", value)
+		}
+	case pkg.FieldTypeInt:
+		if _, ok := value.(int); !ok {
+			return fmt.Errorf("expected a whole number, but got %v", value)
+		}
+	case pkg.FieldTypeEnum:
+		text := pkg.FormatFieldValue(value)
+		for _, allowed := range field.Values {
+			if text == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected one of %v, but got %v", field.Values, value)
+	case pkg.FieldTypeList:
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("expected a list, but got %v", value)
+		}
+		for _, item := range items {
+			switch item.(type) {
+			case string, int, float64, bool:
+			default:
+				return fmt.Errorf("expected list items to be text, but got %v", item)
+			}
+		}
+	}
+	return nil
+}
+
+// DSUFileFields returns the custom fields declared by the DSU training file at path.
+func DSUFileFields(config *pkg.TomeConfig, path string) ([]pkg.FieldDefinition, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	header := pkg.TrainingDefinition[yaml.MapSlice]{}
+	if err := yaml.Unmarshal(fileBytes, &header); err != nil {
+		return nil, err
+	}
+
+	return ResolveFieldDefinitions(config.Root(), header.Meta.Format.Fields, header.Meta.Format.Schema)
+}
@@ -0,0 +1,144 @@
+package validator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+const (
+	icsDateLayout     = "20060102"
+	icsDatetimeLayout = "20060102T150405Z"
+	// icsLineLimit is the maximum length of a content line, in octets, before it is folded.
+	icsLineLimit = 75
+)
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// ICSOptions defines the calendar exported from DSU entries.
+type ICSOptions struct {
+	// MissingEvaluations adds a to-do for each of these entries, which have no evaluation yet.
+	MissingEvaluations []pkg.DSUReport
+	// Stamp is the DTSTAMP of every component, usually the time of the export.
+	Stamp time.Time
+}
+
+// WriteICS writes an iCalendar with an all-day event per DSU entry, summarized
+// by its doing_today and described by its doing_today and blockers, and a
+// to-do per DSU entry missing an evaluation.
+func WriteICS(w io.Writer, entries []pkg.DSUReport, options ICSOptions) error {
+	sorted := make([]pkg.DSUReport, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Datetime.Before(sorted[j].Datetime)
+	})
+
+	stamp := options.Stamp.UTC().Format(icsDatetimeLayout)
+	out := bufio.NewWriter(w)
+	line := func(name string, value string) {
+		writeICSLine(out, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//tome.gg//librarian//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("X-WR-CALNAME", "Daily stand-ups")
+
+	for _, entry := range sorted {
+		date := pkg.DateOf(entry.Datetime)
+		line("BEGIN", "VEVENT")
+		line("UID", dsuEventUID(entry))
+		line("DTSTAMP", stamp)
+		line("DTSTART;VALUE=DATE", date.Format(icsDateLayout))
+		line("DTEND;VALUE=DATE", date.AddDate(0, 0, 1).Format(icsDateLayout))
+		line("SUMMARY", escapeICS(dsuEventSummary(entry)))
+		line("DESCRIPTION", escapeICS(dsuEventDescription(entry)))
+		if tags := entry.EffectiveTags(); len(tags) > 0 {
+			escaped := make([]string, len(tags))
+			for i, tag := range tags {
+				escaped[i] = escapeICS(tag)
+			}
+			line("CATEGORIES", strings.Join(escaped, ","))
+		}
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+
+	for _, entry := range options.MissingEvaluations {
+		date := pkg.DateOf(entry.Datetime)
+		line("BEGIN", "VTODO")
+		line("UID", "evaluation-"+dsuEventUID(entry))
+		line("DTSTAMP", stamp)
+		line("DUE;VALUE=DATE", date.Format(icsDateLayout))
+		line("SUMMARY", escapeICS(fmt.Sprintf("Evaluate the DSU of %s", date.Format(pkg.DateLayout))))
+		line("DESCRIPTION", escapeICS(fmt.Sprintf("DSU %s has no evaluation yet.\n\n%s", entry.ID, dsuEventDescription(entry))))
+		line("RELATED-TO", dsuEventUID(entry))
+		line("STATUS", "NEEDS-ACTION")
+		line("END", "VTODO")
+	}
+
+	line("END", "VCALENDAR")
+
+	return out.Flush()
+}
+
+// dsuEventUID returns the globally unique identifier of the event of a DSU entry.
+func dsuEventUID(e pkg.DSUReport) string {
+	return e.ID + "@tome.gg"
+}
+
+// dsuEventSummary summarizes a DSU entry by the items of its doing_today.
+func dsuEventSummary(e pkg.DSUReport) string {
+	items := SplitListItems(e.DoingToday)
+	if len(items) == 0 {
+		return "DSU"
+	}
+	return "DSU: " + strings.Join(items, "; ")
+}
+
+// dsuEventDescription describes a DSU entry by its doing_today, blockers and custom fields.
+func dsuEventDescription(e pkg.DSUReport) string {
+	description := "Doing today:\n" + strings.TrimSpace(e.DoingToday)
+	if HasBlockers(e) {
+		description += "\n\nBlockers:\n" + strings.TrimSpace(e.Blockers)
+	}
+	if names := e.CustomFieldNames(); len(names) > 0 {
+		description += "\n"
+		for _, name := range names {
+			description += fmt.Sprintf("\n%s: %s", name, strings.TrimSpace(pkg.FormatFieldValue(e.Fields[name])))
+		}
+	}
+	return description
+}
+
+// escapeICS escapes a TEXT value.
+func escapeICS(value string) string {
+	return icsEscaper.Replace(value)
+}
+
+// writeICSLine writes a content line, folded so that no line is longer than
+// 75 octets, without splitting multi-byte characters.
+func writeICSLine(w *bufio.Writer, content string) {
+	limit := icsLineLimit
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(content[cut]) {
+			cut--
+		}
+		w.WriteString(content[:cut] + "\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts towards the limit.
+		limit = icsLineLimit - 1
+	}
+	w.WriteString(content + "\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
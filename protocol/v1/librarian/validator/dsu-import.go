@@ -0,0 +1,490 @@
+package validator
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+// ImportSource defines the format DSUs are imported from.
+type ImportSource string
+
+const (
+	// ImportFromCSV imports one DSU per row of a CSV file with a header row.
+	ImportFromCSV ImportSource = "csv"
+	// ImportFromMarkdown imports DSUs from Markdown daily notes.
+	ImportFromMarkdown ImportSource = "markdown"
+	// ImportFromSlackJSON imports DSUs from the messages of a Slack export.
+	ImportFromSlackJSON ImportSource = "slack-json"
+)
+
+// ParseImportSource parses an import source name.
+func ParseImportSource(value string) (ImportSource, error) {
+	switch source := ImportSource(value); source {
+	case ImportFromCSV, ImportFromMarkdown, ImportFromSlackJSON:
+		return source, nil
+	}
+	return "", fmt.Errorf("unsupported import source %q: expected csv, markdown or slack-json", value)
+}
+
+// ImportOptions defines how DSUs are imported.
+type ImportOptions struct {
+	// Location is the timezone of CSV and Markdown dates without an offset,
+	// and of Slack timestamps.
+	Location *time.Location
+	// Datetime parses the dates of the imported entries, in Location; the
+	// default datetime settings when nil.
+	Datetime *pkg.DatetimeParser
+	// SlackUser restricts a Slack import to the messages of this user ID or name.
+	SlackUser string
+}
+
+// ImportedDSU is an imported DSU, along with where it was read from.
+type ImportedDSU struct {
+	pkg.DSUReport
+	// Source locates the DSU in the imported files, e.g. dsus.csv:12.
+	Source string
+}
+
+// RejectedDSU is an imported DSU that cannot be imported.
+type RejectedDSU struct {
+	Source string
+	Err    error
+}
+
+func (r RejectedDSU) Error() string {
+	return fmt.Sprintf("%s: %s", r.Source, r.Err)
+}
+
+var (
+	isoDatePattern         = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+	markdownHeadingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	slackLabelPattern      = regexp.MustCompile(`(?i)^[\s*_>]*(yesterday|today|blockers?|impediments|remarks|notes)\s*(:)?[*_]*\s*(.*)$`)
+	slackLinkPattern       = regexp.MustCompile(`<([^|>]+)\|([^>]+)>`)
+)
+
+// dsuSections maps the lowercased headings and labels of imported notes to DSU fields.
+var dsuSections = map[string]string{
+	"yesterday":            "done_yesterday",
+	"done yesterday":       "done_yesterday",
+	"done":                 "done_yesterday",
+	"what i did yesterday": "done_yesterday",
+	"today":                "doing_today",
+	"doing today":          "doing_today",
+	"doing":                "doing_today",
+	"plan":                 "doing_today",
+	"what i'll do today":   "doing_today",
+	"blockers":             "blockers",
+	"blocker":              "blockers",
+	"blocked":              "blockers",
+	"impediments":          "blockers",
+	"remarks":              "remarks",
+	"notes":                "remarks",
+}
+
+// ImportDSUs reads the DSUs of a file, or of every file of a directory, sorted
+// by date. Entries without an id are given a name-based UUID derived from their
+// datetime and doing_today, so importing the same notes twice yields the same IDs.
+// Entries without a datetime or breaking the DSU rules are rejected, with where
+// they were read from, and the others are still imported.
+func ImportDSUs(source ImportSource, path string, options ImportOptions) ([]ImportedDSU, []RejectedDSU, error) {
+	if options.Location == nil {
+		options.Location = time.UTC
+	}
+	if options.Datetime == nil {
+		options.Datetime = &pkg.DatetimeParser{}
+	}
+	options.Datetime = options.Datetime.In(options.Location)
+
+	var importFile func(path string, options ImportOptions) ([]ImportedDSU, error)
+	var extensions []string
+	switch source {
+	case ImportFromCSV:
+		importFile, extensions = importCSV, []string{".csv"}
+	case ImportFromMarkdown:
+		importFile, extensions = importMarkdown, []string{".md", ".markdown"}
+	case ImportFromSlackJSON:
+		importFile, extensions = importSlackJSON, []string{".json"}
+	default:
+		return nil, nil, fmt.Errorf("unsupported import source %q", source)
+	}
+
+	files, err := importFiles(path, extensions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries := []ImportedDSU{}
+	rejected := []RejectedDSU{}
+	for _, file := range files {
+		imported, err := importFile(file, options)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to import %s: %w", file, err)
+		}
+		for _, entry := range imported {
+			if err := finalizeImportedDSU(&entry.DSUReport, options.Datetime); err != nil {
+				rejected = append(rejected, RejectedDSU{Source: entry.Source, Err: err})
+				continue
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Datetime.Before(entries[j].Datetime)
+	})
+
+	return entries, rejected, nil
+}
+
+// importFiles lists the path itself, or the files of the directory with one of the extensions.
+func importFiles(path string, extensions []string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	files := []string{}
+	err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		for _, extension := range extensions {
+			if strings.EqualFold(filepath.Ext(file), extension) {
+				files = append(files, file)
+			}
+		}
+		return nil
+	})
+	sort.Strings(files)
+
+	return files, err
+}
+
+// finalizeImportedDSU normalizes the fields of an imported entry, parses its
+// datetime, derives its ID and checks it against the DSU rules.
+func finalizeImportedDSU(e *pkg.DSUReport, parser *pkg.DatetimeParser) error {
+	e.DatetimeRaw = strings.TrimSpace(e.DatetimeRaw)
+	e.DoneYesterday = normalizeImportedField(e.DoneYesterday)
+	e.DoingToday = normalizeImportedField(e.DoingToday)
+	e.Blockers = normalizeImportedField(e.Blockers)
+	e.Remarks = normalizeImportedField(e.Remarks)
+
+	if e.DatetimeRaw == "" {
+		return fmt.Errorf("missing datetime")
+	}
+
+	datetime, err := parser.Parse(e.DatetimeRaw)
+	if err != nil {
+		return fmt.Errorf("failed to parse date %q: %w", e.DatetimeRaw, err)
+	}
+	e.Datetime = datetime
+
+	if e.ID == "" {
+		id, err := pkg.NewNameUUID(pkg.DSUNamespace, e.DatetimeRaw+"\n"+strings.TrimSpace(e.DoingToday))
+		if err != nil {
+			return err
+		}
+		e.ID = id
+	}
+
+	return ValidateDSUEntry(*e)
+}
+
+// normalizeImportedField trims a field and ends it with a newline, as DSU
+// fields are written as block scalars.
+func normalizeImportedField(value string) string {
+	value = strings.TrimSpace(strings.ReplaceAll(value, "\r\n", "\n"))
+	if value == "" {
+		return ""
+	}
+	return value + "\n"
+}
+
+// setDSUField sets a DSU field by its YAML name.
+func setDSUField(e *pkg.DSUReport, field string, value string) {
+	switch field {
+	case "done_yesterday":
+		e.DoneYesterday = value
+	case "doing_today":
+		e.DoingToday = value
+	case "blockers":
+		e.Blockers = value
+	case "remarks":
+		e.Remarks = value
+	}
+}
+
+// csvColumns maps the accepted CSV headers to DSU fields.
+var csvColumns = map[string]string{
+	"id":             "id",
+	"uuid":           "id",
+	"datetime":       "datetime",
+	"date":           "datetime",
+	"done_yesterday": "done_yesterday",
+	"yesterday":      "done_yesterday",
+	"doing_today":    "doing_today",
+	"today":          "doing_today",
+	"blockers":       "blockers",
+	"remarks":        "remarks",
+	"tags":           "tags",
+}
+
+// importCSV reads one DSU per row. The header row names the columns: id,
+// datetime, done_yesterday, doing_today, blockers, remarks and tags (separated
+// by semicolons); only datetime is required.
+func importCSV(path string, options ImportOptions) ([]ImportedDSU, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the header row: %s", err)
+	}
+
+	columns := make([]string, len(header))
+	hasDatetime := false
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[i] = csvColumns[name]
+		hasDatetime = hasDatetime || columns[i] == "datetime"
+	}
+	if !hasDatetime {
+		return nil, fmt.Errorf("missing a datetime column")
+	}
+
+	entries := []ImportedDSU{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		entry := ImportedDSU{Source: fmt.Sprintf("%s:%d", path, line)}
+		for i, value := range record {
+			if i >= len(columns) {
+				break
+			}
+			switch columns[i] {
+			case "id":
+				entry.ID = strings.TrimSpace(value)
+			case "datetime":
+				entry.DatetimeRaw = value
+			case "tags":
+				for _, tag := range strings.Split(value, ";") {
+					if tag = strings.TrimSpace(tag); tag != "" {
+						entry.Tags = append(entry.Tags, tag)
+					}
+				}
+			default:
+				setDSUField(&entry.DSUReport, columns[i], value)
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// importMarkdown reads Markdown daily notes. Yesterday, Today, Blockers and
+// Remarks (or Notes) headings start the DSU fields. A heading with a
+// YYYY-MM-DD date starts a new DSU, so a file can hold several days; otherwise
+// the date is read from the file name.
+func importMarkdown(path string, options ImportOptions) ([]ImportedDSU, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []ImportedDSU{}
+	var current *ImportedDSU
+	field := ""
+	lines := []string{}
+	hasSection := false
+
+	flushField := func() {
+		if current != nil && field != "" {
+			setDSUField(&current.DSUReport, field, strings.Join(lines, "\n"))
+		}
+		field = ""
+		lines = []string{}
+	}
+	flushEntry := func() {
+		flushField()
+		if current != nil && hasSection {
+			entries = append(entries, *current)
+		}
+		current = nil
+		hasSection = false
+	}
+
+	for i, line := range strings.Split(strings.ReplaceAll(string(fileBytes), "\r\n", "\n"), "\n") {
+		source := fmt.Sprintf("%s:%d", path, i+1)
+		heading := markdownHeadingPattern.FindStringSubmatch(line)
+		if heading == nil {
+			if field != "" {
+				lines = append(lines, line)
+			}
+			continue
+		}
+
+		title := strings.ToLower(strings.Trim(strings.TrimSpace(heading[2]), ":*_"))
+		if section, ok := dsuSections[title]; ok {
+			flushField()
+			if current == nil {
+				current = &ImportedDSU{Source: source}
+				current.DatetimeRaw = isoDatePattern.FindString(filepath.Base(path))
+			}
+			field = section
+			hasSection = true
+			continue
+		}
+
+		if date := isoDatePattern.FindString(heading[2]); date != "" {
+			flushEntry()
+			current = &ImportedDSU{Source: source}
+			current.DatetimeRaw = date
+			continue
+		}
+
+		// Any other heading ends the current section.
+		flushField()
+	}
+	flushEntry()
+
+	return entries, nil
+}
+
+// slackMessage is a message of a Slack export.
+type slackMessage struct {
+	Type        string `json:"type"`
+	Subtype     string `json:"subtype"`
+	User        string `json:"user"`
+	Text        string `json:"text"`
+	Ts          string `json:"ts"`
+	UserProfile struct {
+		Name        string `json:"name"`
+		RealName    string `json:"real_name"`
+		DisplayName string `json:"display_name"`
+	} `json:"user_profile"`
+}
+
+// matchesUser returns true if the message was written by the user ID or name.
+func (m slackMessage) matchesUser(user string) bool {
+	if user == "" {
+		return true
+	}
+	for _, candidate := range []string{m.User, m.UserProfile.Name, m.UserProfile.RealName, m.UserProfile.DisplayName} {
+		if candidate != "" && strings.EqualFold(candidate, user) {
+			return true
+		}
+	}
+	return false
+}
+
+// importSlackJSON reads the messages of a Slack export (a JSON array of
+// messages, as exported per channel and day). Messages with Yesterday, Today
+// or Blockers labels become DSUs dated by their timestamp; other messages are
+// ignored.
+func importSlackJSON(path string, options ImportOptions) ([]ImportedDSU, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []slackMessage{}
+	if err := json.Unmarshal(fileBytes, &messages); err != nil {
+		return nil, err
+	}
+
+	entries := []ImportedDSU{}
+	for i, message := range messages {
+		if message.Type != "message" || message.Subtype != "" || !message.matchesUser(options.SlackUser) {
+			continue
+		}
+
+		entry, ok := parseSlackDSU(message.Text)
+		if !ok {
+			continue
+		}
+
+		seconds, err := strconv.ParseFloat(message.Ts, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp %q: %s", message.Ts, err)
+		}
+		entry.DatetimeRaw = time.Unix(int64(seconds), 0).In(options.Location).Format(time.RFC3339)
+
+		entries = append(entries, ImportedDSU{DSUReport: entry, Source: fmt.Sprintf("%s message %d", path, i+1)})
+	}
+
+	return entries, nil
+}
+
+// parseSlackDSU splits a Slack message into DSU fields by its labels, such as
+// "*Yesterday:*" on its own line or followed by the text.
+func parseSlackDSU(text string) (pkg.DSUReport, bool) {
+	entry := pkg.DSUReport{}
+	field := ""
+	lines := []string{}
+	found := false
+
+	flush := func() {
+		if field != "" {
+			setDSUField(&entry, field, strings.Join(lines, "\n"))
+		}
+		lines = []string{}
+	}
+
+	text = slackLinkPattern.ReplaceAllString(text, "[$2]($1)")
+	text = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&").Replace(text)
+
+	for _, line := range strings.Split(text, "\n") {
+		if label := slackLabelPattern.FindStringSubmatch(line); label != nil && (label[2] != "" || strings.TrimSpace(label[3]) == "") {
+			if section, ok := dsuSections[strings.ToLower(label[1])]; ok {
+				flush()
+				field = section
+				found = true
+				if rest := strings.TrimSpace(label[3]); rest != "" {
+					lines = append(lines, rest)
+				}
+				continue
+			}
+		}
+
+		if field != "" {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "• ") || strings.HasPrefix(trimmed, "◦ ") {
+				line = "- " + strings.TrimSpace(trimmed[len("• "):])
+			}
+			lines = append(lines, line)
+		}
+	}
+	flush()
+
+	return entry, found && strings.TrimSpace(entry.DoingToday) != ""
+}
@@ -0,0 +1,208 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RotationPeriod defines the period covered by a single DSU training file.
+type RotationPeriod string
+
+const (
+	// RotateByQuarter splits entries into files such as dsu-reports-q3-2024.yaml
+	RotateByQuarter RotationPeriod = "quarter"
+	// RotateByMonth splits entries into files such as dsu-reports-2024-07.yaml
+	RotateByMonth RotationPeriod = "month"
+	// RotateByYear splits entries into files such as dsu-reports-2024.yaml
+	RotateByYear RotationPeriod = "year"
+)
+
+// DefaultRotationThreshold is the number of entries above which a training
+// file is considered oversized and rotated.
+const DefaultRotationThreshold = 100
+
+var (
+	quarterFilePattern = regexp.MustCompile(`^(.+)-q([1-4])-(\d{4})\.yaml$`)
+	monthFilePattern   = regexp.MustCompile(`^(.+)-(\d{4})-(\d{2})\.yaml$`)
+	yearFilePattern    = regexp.MustCompile(`^(.+)-(\d{4})\.yaml$`)
+)
+
+// ParseRotationPeriod parses a rotation period name.
+func ParseRotationPeriod(s string) (RotationPeriod, error) {
+	switch period := RotationPeriod(strings.ToLower(strings.TrimSpace(s))); period {
+	case RotateByQuarter, RotateByMonth, RotateByYear:
+		return period, nil
+	}
+	return "", fmt.Errorf("unsupported rotation period %q: expected quarter, month or year", s)
+}
+
+// Filename returns the name of the period file holding entries dated t.
+func (p RotationPeriod) Filename(base string, t time.Time) string {
+	switch p {
+	case RotateByQuarter:
+		return fmt.Sprintf("%s-q%d-%d.yaml", base, (int(t.Month())-1)/3+1, t.Year())
+	case RotateByMonth:
+		return fmt.Sprintf("%s-%d-%02d.yaml", base, t.Year(), int(t.Month()))
+	case RotateByYear:
+		return fmt.Sprintf("%s-%d.yaml", base, t.Year())
+	}
+	return base + ".yaml"
+}
+
+// DetectRotationPeriod detects whether the file is a period file, returning
+// its rotation period and the base name shared by its sibling period files.
+func DetectRotationPeriod(path string) (RotationPeriod, string, bool) {
+	name := filepath.Base(path)
+	if match := quarterFilePattern.FindStringSubmatch(name); match != nil {
+		return RotateByQuarter, match[1], true
+	}
+	if match := monthFilePattern.FindStringSubmatch(name); match != nil {
+		return RotateByMonth, match[1], true
+	}
+	if match := yearFilePattern.FindStringSubmatch(name); match != nil {
+		return RotateByYear, match[1], true
+	}
+	return "", strings.TrimSuffix(name, filepath.Ext(name)), false
+}
+
+// RotationMove describes a DSU entry moved into a period file.
+type RotationMove struct {
+	ID   string
+	From string
+	To   string
+}
+
+// RotateDSUFile moves the entries of a DSU training file into the period files
+// matching their dates. Period files are created next to the source file from
+// the source file itself, without its entries, see CreateDSUFileFrom.
+func RotateDSUFile(file DSUFile, period RotationPeriod, dryRun bool) ([]RotationMove, error) {
+	if detected, _, ok := DetectRotationPeriod(file.Filepath); ok && detected == period {
+		return nil, nil // Already a period file
+	}
+
+	_, base, _ := DetectRotationPeriod(file.Filepath)
+	directory := filepath.Dir(file.Filepath)
+
+	fileBytes, err := os.ReadFile(file.Filepath)
+	if err != nil {
+		return nil, err
+	}
+	source := parseTrainingDocument(fileBytes)
+
+	datetimes := map[string]time.Time{}
+	for _, entry := range file.Definition.Content {
+		datetimes[entry.ID] = entry.Datetime
+	}
+
+	moves := []RotationMove{}
+	movedItems := map[string][]itemRange{}
+	for _, item := range source.items() {
+		datetime, ok := datetimes[item.id]
+		if !ok || datetime.IsZero() {
+			logrus.WithField("id", item.id).Warnf("skipping DSU entry without a datetime")
+			continue
+		}
+
+		target := filepath.Join(directory, period.Filename(base, datetime))
+		if target == file.Filepath {
+			continue
+		}
+
+		moves = append(moves, RotationMove{ID: item.id, From: file.Filepath, To: target})
+		movedItems[target] = append(movedItems[target], source.withLeadingComments(item))
+	}
+
+	if dryRun || len(moves) == 0 {
+		return moves, nil
+	}
+
+	targets := make([]string, 0, len(movedItems))
+	for target := range movedItems {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	for _, target := range targets {
+		if err := CreateDSUFileFrom(target, file.Filepath); err != nil {
+			return nil, err
+		}
+
+		targetBytes, err := os.ReadFile(target)
+		if err != nil {
+			return nil, err
+		}
+
+		doc := parseTrainingDocument(targetBytes)
+		for _, item := range movedItems[target] {
+			lines := reindentLines(source.lines[item.start:item.end], source.itemIndent(), doc.itemIndent())
+			doc.appendItem(lines)
+		}
+
+		if err := writeFilePreservingMode(target, doc.bytes()); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, move := range moves {
+		item, err := source.findItem(move.ID)
+		if err != nil {
+			return nil, err
+		}
+		source.replaceItem(source.withLeadingComments(item), nil)
+	}
+
+	if len(source.items()) == 0 && source.content != -1 {
+		source.lines[source.content] = "content: []"
+	}
+
+	return moves, writeFilePreservingMode(file.Filepath, source.bytes())
+}
+
+// CreateDSUFileFrom creates an empty DSU training file from an existing
+// training file, keeping everything but its entries: the tomegg and meta
+// header, other keys and comments. Nothing is done when the file already exists.
+func CreateDSUFileFrom(path string, source string) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	sourceBytes, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+
+	doc := parseTrainingDocument(sourceBytes)
+	lines := doc.lines
+	if doc.content != -1 {
+		lines = append(append([]string{}, doc.lines[:doc.content]...), doc.lines[doc.end:]...)
+	}
+	header := strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n\ncontent:\n"
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(header), 0644)
+}
+
+// reindentLines moves lines from one list item indentation to another.
+func reindentLines(lines []string, from string, to string) []string {
+	reindented := make([]string, len(lines))
+	for i, line := range lines {
+		if strings.HasPrefix(line, from) {
+			line = to + strings.TrimPrefix(line, from)
+		}
+		reindented[i] = line
+	}
+	return reindented
+}
@@ -0,0 +1,200 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+// SearchableDSUFields lists the DSU fields covered by full-text search.
+var SearchableDSUFields = []string{"done_yesterday", "doing_today", "blockers", "remarks"}
+
+type (
+	// DSUQuery defines a full-text search over DSU entries.
+	DSUQuery struct {
+		// Terms must all appear in the searched fields of an entry.
+		Terms []string
+		// Fields restricts the search to these fields; all searchable and custom fields when empty.
+		Fields []string
+		// Since and Until restrict the entry dates, inclusive. Zero values are unbounded.
+		Since time.Time
+		Until time.Time
+		// Tags must all be among the effective tags of an entry.
+		Tags []string
+		// HasBlockers restricts the search to entries reporting blockers.
+		HasBlockers bool
+	}
+
+	// DSUMatch is a DSU entry matching a query.
+	DSUMatch struct {
+		ID       string       `json:"id"`
+		Date     time.Time    `json:"date"`
+		File     string       `json:"file"`
+		Snippets []DSUSnippet `json:"snippets"`
+	}
+
+	// DSUSnippet is a line of a DSU field containing a search term.
+	DSUSnippet struct {
+		Field string `json:"field"`
+		Text  string `json:"text"`
+	}
+)
+
+// DSUField returns the value of a DSU field by its YAML name, including custom fields.
+func DSUField(e pkg.DSUReport, field string) (string, error) {
+	if value, ok := e.Fields[field]; ok {
+		return pkg.FormatFieldValue(value), nil
+	}
+
+	switch field {
+	case "done_yesterday":
+		return e.DoneYesterday, nil
+	case "doing_today":
+		return e.DoingToday, nil
+	case "blockers":
+		return e.Blockers, nil
+	case "remarks":
+		return e.Remarks, nil
+	}
+	return "", fmt.Errorf("unsupported DSU field %q: expected one of %s, or a custom field", field, strings.Join(SearchableDSUFields, ", "))
+}
+
+// InDateRange returns true if the date of t falls between since and until,
+// inclusive. Zero values are unbounded.
+func InDateRange(t time.Time, since time.Time, until time.Time) bool {
+	date := pkg.DateOf(t)
+	if !since.IsZero() && date.Before(pkg.DateOf(since)) {
+		return false
+	}
+	if !until.IsZero() && date.After(pkg.DateOf(until)) {
+		return false
+	}
+	return true
+}
+
+// SearchDSUs searches the entries of the DSU files, returning matches sorted by date.
+func SearchDSUs(files []DSUFile, query DSUQuery) ([]DSUMatch, error) {
+	customFields := map[string]bool{}
+	for _, file := range files {
+		for _, entry := range file.Definition.Content {
+			for _, name := range entry.CustomFieldNames() {
+				customFields[name] = true
+			}
+		}
+	}
+	for _, field := range query.Fields {
+		if _, err := DSUField(pkg.DSUReport{}, field); err != nil && !customFields[field] {
+			return nil, err
+		}
+	}
+
+	terms := []string{}
+	seen := map[string]bool{}
+	for _, term := range query.Terms {
+		term = strings.ToLower(strings.TrimSpace(term))
+		if term != "" && !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	matches := []DSUMatch{}
+	for _, file := range files {
+		for _, entry := range file.Definition.Content {
+			if !InDateRange(entry.Datetime, query.Since, query.Until) {
+				continue
+			}
+			if !entry.HasTags(query.Tags) {
+				continue
+			}
+			if query.HasBlockers && !HasBlockers(entry) {
+				continue
+			}
+
+			fields := query.Fields
+			if len(fields) == 0 {
+				fields = append(append([]string{}, SearchableDSUFields...), entry.CustomFieldNames()...)
+			}
+
+			match, ok := matchDSU(entry, fields, terms)
+			if !ok {
+				continue
+			}
+			match.File = file.Filepath
+			matches = append(matches, match)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Date.Before(matches[j].Date)
+	})
+
+	return matches, nil
+}
+
+// matchDSU checks that every term appears in the fields of the entry, and
+// collects the lines containing them.
+func matchDSU(entry pkg.DSUReport, fields []string, terms []string) (DSUMatch, bool) {
+	match := DSUMatch{
+		ID:       entry.ID,
+		Date:     entry.Datetime,
+		Snippets: []DSUSnippet{},
+	}
+
+	found := map[string]bool{}
+	for _, field := range fields {
+		value, err := DSUField(entry, field)
+		if err != nil {
+			continue // A custom field the entry does not have
+		}
+		for _, line := range strings.Split(value, "\n") {
+			lower := strings.ToLower(line)
+			matched := false
+			for _, term := range terms {
+				if strings.Contains(lower, term) {
+					found[term] = true
+					matched = true
+				}
+			}
+			if matched {
+				match.Snippets = append(match.Snippets, DSUSnippet{Field: field, Text: strings.TrimSpace(line)})
+			}
+		}
+	}
+
+	return match, len(found) == len(terms)
+}
+
+// TermRanges returns the byte ranges of the case-insensitive occurrences of
+// the terms in the text, sorted and with overlapping ranges merged.
+func TermRanges(text string, terms []string) [][]int {
+	ranges := [][]int{}
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		pattern := regexp.MustCompile("(?i)" + regexp.QuoteMeta(term))
+		ranges = append(ranges, pattern.FindAllStringIndex(text, -1)...)
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i][0] < ranges[j][0]
+	})
+
+	merged := [][]int{}
+	for _, r := range ranges {
+		if last := len(merged) - 1; last >= 0 && r[0] <= merged[last][1] {
+			if r[1] > merged[last][1] {
+				merged[last][1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, []int{r[0], r[1]})
+	}
+	return merged
+}
@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+// StreakReport summarizes how consistently DSUs were written. Streaks count
+// consecutive working days with a DSU; days off neither extend nor break them.
+type StreakReport struct {
+	Current      int
+	CurrentStart time.Time
+
+	Longest      int
+	LongestStart time.Time
+	LongestEnd   time.Time
+
+	LastEntry time.Time
+}
+
+// dsuDates returns the set of dates with at least one DSU entry.
+func dsuDates(entries []pkg.DSUReport) map[time.Time]bool {
+	dates := map[time.Time]bool{}
+	for _, entry := range entries {
+		if entry.Datetime.IsZero() {
+			continue
+		}
+		dates[pkg.DateOf(entry.Datetime)] = true
+	}
+	return dates
+}
+
+// firstDSUDate returns the date of the earliest DSU entry.
+func firstDSUDate(dates map[time.Time]bool) time.Time {
+	var first time.Time
+	for date := range dates {
+		if first.IsZero() || date.Before(first) {
+			first = date
+		}
+	}
+	return first
+}
+
+// ComputeStreaks computes the current and longest DSU streaks up to today.
+// A working day without a DSU only breaks the current streak once it is over.
+func ComputeStreaks(entries []pkg.DSUReport, calendar *pkg.Calendar, today time.Time) StreakReport {
+	report := StreakReport{}
+
+	dates := dsuDates(entries)
+	if len(dates) == 0 {
+		return report
+	}
+
+	today = pkg.DateOf(today)
+	for date := range dates {
+		if date.After(report.LastEntry) {
+			report.LastEntry = date
+		}
+	}
+	if report.LastEntry.After(today) {
+		today = report.LastEntry
+	}
+
+	for day := firstDSUDate(dates); !day.After(today); day = day.AddDate(0, 0, 1) {
+		if !calendar.IsWorkingDay(day) {
+			continue
+		}
+
+		if !dates[day] {
+			if !day.Equal(today) {
+				report.Current = 0
+			}
+			continue
+		}
+
+		if report.Current == 0 {
+			report.CurrentStart = day
+		}
+		report.Current++
+
+		if report.Current > report.Longest {
+			report.Longest = report.Current
+			report.LongestStart = report.CurrentStart
+			report.LongestEnd = day
+		}
+	}
+
+	return report
+}
+
+// FindGaps returns the working days between from and until, inclusive, that
+// have no DSU entry.
+func FindGaps(entries []pkg.DSUReport, calendar *pkg.Calendar, from time.Time, until time.Time) []time.Time {
+	dates := dsuDates(entries)
+	gaps := []time.Time{}
+
+	for day := pkg.DateOf(from); !day.After(pkg.DateOf(until)); day = day.AddDate(0, 0, 1) {
+		if calendar.IsWorkingDay(day) && !dates[day] {
+			gaps = append(gaps, day)
+		}
+	}
+
+	return gaps
+}
+
+// FirstDSUDate returns the date of the earliest DSU entry, or the zero time if there is none.
+func FirstDSUDate(entries []pkg.DSUReport) time.Time {
+	return firstDSUDate(dsuDates(entries))
+}
@@ -3,24 +3,71 @@ package validator
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
 	"gopkg.in/yaml.v2"
 )
 
+// blockerPlaceholders are the values apprentices write when they have no blockers
+var blockerPlaceholders = map[string]bool{
+	"":            true,
+	"none":        true,
+	"none so far": true,
+	"n/a":         true,
+	"na":          true,
+	"nil":         true,
+	"nothing":     true,
+	"no":          true,
+	"no blockers": true,
+	"no blocker":  true,
+	"-":           true,
+}
+
+var listMarkerPattern = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+`)
+
+// IsBlockerPlaceholder returns true if the blocker text only says there are no blockers
+func IsBlockerPlaceholder(text string) bool {
+	for _, line := range strings.Split(text, "\n") {
+		line = listMarkerPattern.ReplaceAllString(line, "")
+		line = strings.ToLower(strings.Trim(strings.TrimSpace(line), ".!"))
+		if !blockerPlaceholders[line] {
+			return false
+		}
+	}
+	return true
+}
+
+// HasBlockers returns true if the DSU entry reports actual blockers
+func HasBlockers(e pkg.DSUReport) bool {
+	return !IsBlockerPlaceholder(e.Blockers)
+}
+
 // FindMissingEvaluations returns DSU entries that don't have corresponding self evaluations
 // If limitToLast3 is true, returns only the 3 most recent entries in ascending order
 func FindMissingEvaluations(plan *pkg.ValidationPlan, limitToLast3 bool) ([]pkg.DSUReport, error) {
+	return FindMissingEvaluationsBy(plan, "", nil, limitToLast3)
+}
+
+// FindMissingEvaluationsBy returns DSU entries that the evaluator has not evaluated,
+// or that nobody has evaluated when evaluator is empty. The evaluator is matched
+// against the name, email and eth of the evaluator of each evaluation file.
+// Only entries having all of the tags are considered.
+// If limitToLast3 is true, returns only the 3 most recent entries in ascending order
+func FindMissingEvaluationsBy(plan *pkg.ValidationPlan, evaluator string, tags []string, limitToLast3 bool) ([]pkg.DSUReport, error) {
 	// First, collect all DSU entries
 	dsuEntries, err := getAllDSUEntries(plan)
 	if err != nil {
 		return nil, err
 	}
+	dsuEntries = FilterDSUsByTags(dsuEntries, tags)
 
-	// Then, collect all evaluation IDs
-	evaluationIDs, err := getAllEvaluationIDs(plan)
+	// Then, collect the IDs evaluated by the evaluator
+	evaluationIDs, err := getAllEvaluationIDs(plan, evaluator)
 	if err != nil {
 		return nil, err
 	}
@@ -69,9 +116,20 @@ func GetDSUByUUID(plan *pkg.ValidationPlan, uuid string) (*pkg.DSUReport, error)
 	return nil, fmt.Errorf("DSU entry with UUID %s not found", uuid)
 }
 
-// getAllDSUEntries collects all DSU entries from training files
-func getAllDSUEntries(plan *pkg.ValidationPlan) ([]pkg.DSUReport, error) {
-	var allEntries []pkg.DSUReport
+// DSUFile pairs a DSU training file with its parsed definition.
+type DSUFile struct {
+	Filepath   string
+	Definition pkg.TrainingDefinition[pkg.DSUReport]
+}
+
+// GetDSUFiles collects all DSU training files found in the plan
+func GetDSUFiles(plan *pkg.ValidationPlan) ([]DSUFile, error) {
+	var dsuFiles []DSUFile
+
+	parser, err := plan.Config.DatetimeParser()
+	if err != nil {
+		return nil, fmt.Errorf("invalid datetime config: %s", err)
+	}
 
 	for _, file := range plan.Files {
 		if !strings.Contains(file.Filepath, "dsu") || !strings.Contains(file.Filepath, "training") {
@@ -83,9 +141,9 @@ func getAllDSUEntries(plan *pkg.ValidationPlan) ([]pkg.DSUReport, error) {
 			continue // Skip files we can't read
 		}
 
-		var result = pkg.TrainingDefinition[pkg.DSUReport]{}
-		err = yaml.Unmarshal(fileBytes, &result)
+		result, err := decodeDSUDefinition(fileBytes)
 		if err != nil {
+			logrus.WithField("file", file.Filepath).Warnf("skipping DSU file: %s", err)
 			continue // Skip invalid YAML files
 		}
 
@@ -93,12 +151,112 @@ func getAllDSUEntries(plan *pkg.ValidationPlan) ([]pkg.DSUReport, error) {
 			continue // Skip non-DSU training files
 		}
 
-		allEntries = append(allEntries, result.Content...)
+		for i := range result.Content {
+			entry := &result.Content[i]
+			// Entries whose datetime fails to parse are kept, undated, so that
+			// they can still be listed and fixed; validate reports the error.
+			if err := entry.ParseDatetime(parser); err != nil {
+				logrus.WithField("file", file.Filepath).Warnf("DSU entry is undated: %s", err)
+			}
+			entry.FileTags = result.Meta.Tags
+		}
+
+		dsuFiles = append(dsuFiles, DSUFile{
+			Filepath:   file.Filepath,
+			Definition: result,
+		})
+	}
+
+	return dsuFiles, nil
+}
+
+// decodeDSUDefinition decodes a DSU training file, leaving the datetimes of its
+// entries to be parsed with the datetime settings of the repository.
+func decodeDSUDefinition(fileBytes []byte) (pkg.TrainingDefinition[pkg.DSUReport], error) {
+	var unparsed = pkg.TrainingDefinition[pkg.UnparsedDSUReport]{}
+	if err := yaml.Unmarshal(fileBytes, &unparsed); err != nil {
+		return pkg.TrainingDefinition[pkg.DSUReport]{}, err
+	}
+
+	result := pkg.TrainingDefinition[pkg.DSUReport]{
+		Tomegg:  unparsed.Tomegg,
+		Meta:    unparsed.Meta,
+		Content: make([]pkg.DSUReport, len(unparsed.Content)),
+	}
+	for i, entry := range unparsed.Content {
+		result.Content[i] = pkg.DSUReport(entry)
+	}
+	return result, nil
+}
+
+// FindDSUEntry retrieves a DSU entry by its UUID along with the training file it was found in
+func FindDSUEntry(plan *pkg.ValidationPlan, uuid string) (*DSUFile, *pkg.DSUReport, error) {
+	dsuFiles, err := GetDSUFiles(plan)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range dsuFiles {
+		for j := range dsuFiles[i].Definition.Content {
+			if dsuFiles[i].Definition.Content[j].ID == uuid {
+				return &dsuFiles[i], &dsuFiles[i].Definition.Content[j], nil
+			}
+		}
+	}
+
+	return nil, nil, fmt.Errorf("DSU entry with UUID %s not found", uuid)
+}
+
+// getAllDSUEntries collects all DSU entries from training files
+func getAllDSUEntries(plan *pkg.ValidationPlan) ([]pkg.DSUReport, error) {
+	var allEntries []pkg.DSUReport
+
+	dsuFiles, err := GetDSUFiles(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range dsuFiles {
+		allEntries = append(allEntries, file.Definition.Content...)
 	}
 
 	return allEntries, nil
 }
 
+// GetAllDSUEntries collects all DSU entries from training files
+func GetAllDSUEntries(plan *pkg.ValidationPlan) ([]pkg.DSUReport, error) {
+	return getAllDSUEntries(plan)
+}
+
+// FilterDSUsByTags returns the entries having all of the tags
+func FilterDSUsByTags(entries []pkg.DSUReport, tags []string) []pkg.DSUReport {
+	if len(tags) == 0 {
+		return entries
+	}
+
+	filtered := []pkg.DSUReport{}
+	for _, entry := range entries {
+		if entry.HasTags(tags) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// FilterDSUFilesByTags returns the DSU files with only their entries having all of the tags
+func FilterDSUFilesByTags(files []DSUFile, tags []string) []DSUFile {
+	if len(tags) == 0 {
+		return files
+	}
+
+	filtered := make([]DSUFile, len(files))
+	for i, file := range files {
+		filtered[i] = file
+		filtered[i].Definition.Content = FilterDSUsByTags(file.Definition.Content, tags)
+	}
+	return filtered
+}
+
 // GetLatestDSU retrieves the most recent DSU entry by date
 func GetLatestDSU(plan *pkg.ValidationPlan) (*pkg.DSUReport, error) {
 	dsuEntries, err := getAllDSUEntries(plan)
@@ -121,10 +279,91 @@ func GetLatestDSU(plan *pkg.ValidationPlan) (*pkg.DSUReport, error) {
 	return latestEntry, nil
 }
 
-// getAllEvaluationIDs collects all evaluation IDs from evaluation files
-func getAllEvaluationIDs(plan *pkg.ValidationPlan) ([]string, error) {
+// getAllEvaluationIDs collects all evaluation IDs from evaluation files,
+// only from the files of the evaluator unless it is empty. Meta evaluations
+// of the mentor are not evaluations of the training, and are left out.
+func getAllEvaluationIDs(plan *pkg.ValidationPlan, evaluator string) ([]string, error) {
 	var allIDs []string
 
+	evaluationFiles, err := GetEvaluationFiles(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range evaluationFiles {
+		if file.IsMeta() || (evaluator != "" && !file.EvaluatedBy(evaluator)) {
+			continue
+		}
+
+		for _, evaluation := range file.Definition.Evaluations {
+			allIDs = append(allIDs, evaluation.ID)
+		}
+	}
+
+	return allIDs, nil
+}
+
+// GetEvaluators maps the ID of each evaluated training entry to the names of
+// its evaluators, in the order of the evaluation files. Meta evaluations are left out.
+func GetEvaluators(plan *pkg.ValidationPlan) (map[string][]string, error) {
+	evaluationFiles, err := GetEvaluationFiles(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluators := map[string][]string{}
+	for _, file := range evaluationFiles {
+		if file.IsMeta() {
+			continue
+		}
+		name := file.EvaluatorName()
+		for _, evaluation := range file.Definition.Evaluations {
+			known := false
+			for _, evaluator := range evaluators[evaluation.ID] {
+				if evaluator == name {
+					known = true
+					break
+				}
+			}
+			if !known {
+				evaluators[evaluation.ID] = append(evaluators[evaluation.ID], name)
+			}
+		}
+	}
+
+	return evaluators, nil
+}
+
+// GetLatestDSUFile retrieves the DSU training file holding the most recent entry
+func GetLatestDSUFile(plan *pkg.ValidationPlan) (*DSUFile, error) {
+	dsuFiles, err := GetDSUFiles(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	var latestFile *DSUFile
+	var latestEntry *pkg.DSUReport
+	for i := range dsuFiles {
+		for j := range dsuFiles[i].Definition.Content {
+			entry := &dsuFiles[i].Definition.Content[j]
+			if latestEntry == nil || entry.Datetime.After(latestEntry.Datetime) {
+				latestFile = &dsuFiles[i]
+				latestEntry = entry
+			}
+		}
+	}
+
+	if latestFile == nil {
+		return nil, fmt.Errorf("no DSU entries found")
+	}
+
+	return latestFile, nil
+}
+
+// FindEvaluationReferences returns the evaluation files that reference the given training ID
+func FindEvaluationReferences(plan *pkg.ValidationPlan, id string) ([]string, error) {
+	var references []string
+
 	for _, file := range plan.Files {
 		if !strings.Contains(file.Filepath, "evaluations") {
 			continue
@@ -135,7 +374,7 @@ func getAllEvaluationIDs(plan *pkg.ValidationPlan) ([]string, error) {
 			continue // Skip files we can't read
 		}
 
-		result := pkg.EvaluationDefinition[pkg.StandardMeasurement]{}
+		result := pkg.EvaluationDefinition[pkg.Measurement]{}
 		err = yaml.Unmarshal(fileBytes, &result)
 		if err != nil {
 			continue // Skip invalid YAML files
@@ -146,9 +385,71 @@ func getAllEvaluationIDs(plan *pkg.ValidationPlan) ([]string, error) {
 		}
 
 		for _, evaluation := range result.Evaluations {
-			allIDs = append(allIDs, evaluation.ID)
+			if evaluation.ID == id {
+				references = append(references, file.Filepath)
+				break
+			}
 		}
 	}
 
-	return allIDs, nil
-}
\ No newline at end of file
+	return references, nil
+}
+
+// DSUListing summarizes a DSU entry for listings
+type DSUListing struct {
+	ID          string    `json:"id" yaml:"id"`
+	Datetime    time.Time `json:"datetime" yaml:"datetime"`
+	File        string    `json:"file" yaml:"file"`
+	Tags        []string  `json:"tags" yaml:"tags"`
+	HasBlockers bool      `json:"has_blockers" yaml:"has_blockers"`
+	Evaluated   bool      `json:"evaluated" yaml:"evaluated"`
+	// EvaluatedBy lists the evaluators of the entry.
+	EvaluatedBy []string `json:"evaluated_by,omitempty" yaml:"evaluated_by,omitempty"`
+	// Fields holds the custom field values of the entry, keyed by name.
+	Fields map[string]interface{} `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// ListDSUs lists the DSU entries dated between since and until, inclusive, and having all of the tags, in ascending order by date
+func ListDSUs(plan *pkg.ValidationPlan, since time.Time, until time.Time, tags []string) ([]DSUListing, error) {
+	dsuFiles, err := GetDSUFiles(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluators, err := GetEvaluators(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	listings := []DSUListing{}
+	for _, file := range dsuFiles {
+		for _, entry := range file.Definition.Content {
+			if !InDateRange(entry.Datetime, since, until) || !entry.HasTags(tags) {
+				continue
+			}
+
+			listing := DSUListing{
+				ID:          entry.ID,
+				Datetime:    entry.Datetime,
+				File:        file.Filepath,
+				Tags:        entry.EffectiveTags(),
+				HasBlockers: HasBlockers(entry),
+				Evaluated:   len(evaluators[entry.ID]) > 0,
+				EvaluatedBy: evaluators[entry.ID],
+			}
+			for _, name := range entry.CustomFieldNames() {
+				if listing.Fields == nil {
+					listing.Fields = map[string]interface{}{}
+				}
+				listing.Fields[name] = pkg.PlainFieldValue(entry.Fields[name])
+			}
+			listings = append(listings, listing)
+		}
+	}
+
+	sort.SliceStable(listings, func(i, j int) bool {
+		return listings[i].Datetime.Before(listings[j].Datetime)
+	})
+
+	return listings, nil
+}
@@ -0,0 +1,382 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	"gopkg.in/yaml.v2"
+)
+
+// The DSU writer edits training files at the text level instead of
+// re-marshalling them, so that comments and formatting written by the
+// apprentice are kept intact.
+
+var (
+	topLevelKeyPattern = regexp.MustCompile(`^[^\s#-][^:]*:`)
+	listItemPattern    = regexp.MustCompile(`^(\s*)- `)
+	itemIDPattern      = regexp.MustCompile(`^\s*(?:- )?id:\s*["']?([^"'\s#]+)["']?`)
+)
+
+const dsuFileHeader = `# The following definition enables the Tome.gg librarian to recognize this YAML format
+# as conforming to the 0.1.0 version of the training definition.
+tomegg:
+  type: training
+  version: 0.1.0
+  definition: https://protocol.tome.gg/training/0.1.0
+
+# Meta information about this report
+meta:
+  format:
+    type: dsu
+    version: 0.1.0
+    definition: https://protocol.tome.gg/formats/dsu/0.1.0
+`
+
+// trainingDocument is a line-based view over a training file, or any file
+// holding its entries in a top-level sequence.
+type trainingDocument struct {
+	lines []string
+	// key is the top-level key of the sequence of entries, e.g. content.
+	key        string
+	keyPattern *regexp.Regexp
+	// content is the index of the key line, -1 when missing.
+	content int
+	// end is the exclusive end of the content block.
+	end int
+}
+
+// itemRange is the span of lines of a single content entry.
+type itemRange struct {
+	id    string
+	start int
+	end   int
+}
+
+func parseTrainingDocument(fileBytes []byte) *trainingDocument {
+	return parseSequenceDocument(fileBytes, "content")
+}
+
+// parseSequenceDocument parses a file holding its entries under the top-level key.
+func parseSequenceDocument(fileBytes []byte, key string) *trainingDocument {
+	doc := &trainingDocument{
+		lines:      strings.Split(strings.TrimRight(string(fileBytes), "\n"), "\n"),
+		key:        key,
+		keyPattern: regexp.MustCompile(`^` + regexp.QuoteMeta(key) + `:\s*(\[\s*\])?\s*(#.*)?$`),
+		content:    -1,
+	}
+	doc.index()
+	return doc
+}
+
+// index locates the content block within the document.
+func (d *trainingDocument) index() {
+	d.content = -1
+	d.end = len(d.lines)
+
+	for i, line := range d.lines {
+		if d.content == -1 {
+			if d.keyPattern.MatchString(line) {
+				d.content = i
+			}
+			continue
+		}
+		if topLevelKeyPattern.MatchString(line) {
+			d.end = i
+			break
+		}
+	}
+
+	// Trailing blank lines and comments belong to whatever follows.
+	for d.content != -1 && d.end > d.content+1 {
+		trimmed := strings.TrimSpace(d.lines[d.end-1])
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+		d.end--
+	}
+}
+
+// itemIndent returns the indentation used by the list items of the content block.
+func (d *trainingDocument) itemIndent() string {
+	if d.content == -1 {
+		return "  "
+	}
+	for _, line := range d.lines[d.content+1 : d.end] {
+		if match := listItemPattern.FindStringSubmatch(line); match != nil {
+			return match[1]
+		}
+	}
+	return "  "
+}
+
+// items returns the line ranges of every entry in the content block.
+func (d *trainingDocument) items() []itemRange {
+	if d.content == -1 {
+		return nil
+	}
+
+	prefix := d.itemIndent() + "- "
+	items := []itemRange{}
+	for i := d.content + 1; i < d.end; i++ {
+		if !strings.HasPrefix(d.lines[i], prefix) {
+			continue
+		}
+		if len(items) > 0 {
+			items[len(items)-1].end = i
+		}
+		items = append(items, itemRange{start: i, end: d.end})
+	}
+
+	for i := range items {
+		item := &items[i]
+
+		// Comments and blank lines before the next entry belong to that entry.
+		for item.end > item.start+1 {
+			trimmed := strings.TrimSpace(d.lines[item.end-1])
+			if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+				break
+			}
+			item.end--
+		}
+
+		for j := item.start; j < item.end; j++ {
+			line := d.lines[j]
+			if j != item.start && len(line)-len(strings.TrimLeft(line, " ")) != len(prefix) {
+				continue
+			}
+			if match := itemIDPattern.FindStringSubmatch(line); match != nil {
+				item.id = match[1]
+				break
+			}
+		}
+	}
+
+	return items
+}
+
+// findItem returns the range of the entry with the given ID.
+func (d *trainingDocument) findItem(id string) (itemRange, error) {
+	for _, item := range d.items() {
+		if item.id == id {
+			return item, nil
+		}
+	}
+	return itemRange{}, fmt.Errorf("DSU entry with UUID %s not found", id)
+}
+
+// withLeadingComments extends the range over the comments directly above the entry.
+func (d *trainingDocument) withLeadingComments(item itemRange) itemRange {
+	for item.start > d.content+1 && strings.HasPrefix(strings.TrimSpace(d.lines[item.start-1]), "#") {
+		item.start--
+	}
+	return item
+}
+
+// replaceItem replaces the lines of an entry with new lines.
+func (d *trainingDocument) replaceItem(item itemRange, replacement []string) {
+	lines := make([]string, 0, len(d.lines)-(item.end-item.start)+len(replacement))
+	lines = append(lines, d.lines[:item.start]...)
+	lines = append(lines, replacement...)
+	lines = append(lines, d.lines[item.end:]...)
+	d.lines = lines
+	d.index()
+}
+
+func (d *trainingDocument) bytes() []byte {
+	return []byte(strings.Join(d.lines, "\n") + "\n")
+}
+
+// appendItem appends the rendered entry lines to the end of the content block.
+func (d *trainingDocument) appendItem(item []string) {
+	if d.content == -1 {
+		d.lines = append(d.lines, "", d.key+":")
+		d.index()
+	}
+
+	// Replace an empty flow sequence (content: []) with a block sequence.
+	d.lines[d.content] = d.key + ":"
+
+	lines := make([]string, 0, len(d.lines)+len(item))
+	lines = append(lines, d.lines[:d.end]...)
+	lines = append(lines, item...)
+	lines = append(lines, d.lines[d.end:]...)
+	d.lines = lines
+	d.index()
+}
+
+// RenderDSUEntry renders a DSU entry as a YAML mapping, using literal blocks
+// for the Markdown fields.
+func RenderDSUEntry(e pkg.DSUReport) string {
+	return strings.Join(renderDSUFields(e), "\n") + "\n"
+}
+
+func renderDSUFields(e pkg.DSUReport) []string {
+	lines := []string{
+		fmt.Sprintf("id: %s", e.ID),
+		fmt.Sprintf("datetime: %s", e.DatetimeRaw),
+	}
+	if len(e.Tags) > 0 {
+		lines = append(lines, fmt.Sprintf("tags: [%s]", strings.Join(quoteFlowScalars(e.Tags), ", ")))
+	}
+	lines = append(lines, renderBlockScalar("remarks", e.Remarks)...)
+	lines = append(lines, renderBlockScalar("done_yesterday", e.DoneYesterday)...)
+	lines = append(lines, renderBlockScalar("doing_today", e.DoingToday)...)
+	lines = append(lines, renderBlockScalar("blockers", e.Blockers)...)
+	for _, name := range e.CustomFieldNames() {
+		lines = append(lines, renderCustomField(name, e.Fields[name])...)
+	}
+	return lines
+}
+
+// renderCustomField renders a custom field, as a block scalar for multi-line text.
+func renderCustomField(name string, value interface{}) []string {
+	if text, ok := value.(string); ok && strings.Contains(text, "\n") {
+		return renderBlockScalar(name, text)
+	}
+
+	out, err := yaml.Marshal(map[string]interface{}{name: value})
+	if err != nil {
+		return []string{fmt.Sprintf("%s: %s", name, strconv.Quote(pkg.FormatFieldValue(value)))}
+	}
+	return strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+}
+
+// renderListItem renders the entry as a sequence item at the given indentation.
+func renderListItem(fields []string, indent string) []string {
+	item := make([]string, len(fields))
+	for i, line := range fields {
+		prefix := indent + "  "
+		if i == 0 {
+			prefix = indent + "- "
+		}
+		if line == "" {
+			item[i] = ""
+			continue
+		}
+		item[i] = prefix + line
+	}
+	return item
+}
+
+func renderBlockScalar(key string, value string) []string {
+	if value == "" {
+		return []string{fmt.Sprintf("%s: \"\"", key)}
+	}
+
+	indicator := "|"
+	if strings.HasPrefix(value, " ") {
+		indicator += "2"
+	}
+
+	body := strings.TrimSuffix(value, "\n")
+	switch {
+	case !strings.HasSuffix(value, "\n"):
+		indicator += "-"
+	case strings.HasSuffix(body, "\n"):
+		indicator += "+"
+	}
+
+	lines := []string{fmt.Sprintf("%s: %s", key, indicator)}
+	for _, line := range strings.Split(body, "\n") {
+		if line == "" {
+			lines = append(lines, "")
+			continue
+		}
+		lines = append(lines, "  "+line)
+	}
+	return lines
+}
+
+// quoteFlowScalars quotes the values that cannot be written as plain scalars in a flow sequence.
+func quoteFlowScalars(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		if value == "" || strings.ContainsAny(value, ",[]{}:#&*!|>'\"%@`") || strings.TrimSpace(value) != value {
+			value = strconv.Quote(value)
+		}
+		quoted[i] = value
+	}
+	return quoted
+}
+
+// CreateDSUFile creates an empty DSU training file with the tomegg and meta headers.
+func CreateDSUFile(path string, tags []string) error {
+	header := dsuFileHeader
+	if len(tags) > 0 {
+		header += "  tags:\n"
+		for _, tag := range tags {
+			header += fmt.Sprintf("    - %s\n", tag)
+		}
+	}
+	header += "\ncontent:\n"
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(header), 0644)
+}
+
+// AppendDSUEntry appends the entry to the content of a DSU training file,
+// leaving the rest of the file untouched.
+func AppendDSUEntry(path string, e pkg.DSUReport) error {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	doc := parseTrainingDocument(fileBytes)
+	doc.appendItem(renderListItem(renderDSUFields(e), doc.itemIndent()))
+
+	return writeFilePreservingMode(path, doc.bytes())
+}
+
+func writeFilePreservingMode(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	return os.WriteFile(path, data, mode)
+}
+
+// ReplaceDSUEntry replaces the entry with the given ID in a DSU training file,
+// leaving the other entries and comments untouched.
+func ReplaceDSUEntry(path string, id string, e pkg.DSUReport) error {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	doc := parseTrainingDocument(fileBytes)
+	item, err := doc.findItem(id)
+	if err != nil {
+		return err
+	}
+
+	doc.replaceItem(item, renderListItem(renderDSUFields(e), doc.itemIndent()))
+
+	return writeFilePreservingMode(path, doc.bytes())
+}
+
+// RemoveDSUEntry removes the entry with the given ID from a DSU training file.
+func RemoveDSUEntry(path string, id string) error {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	doc := parseTrainingDocument(fileBytes)
+	item, err := doc.findItem(id)
+	if err != nil {
+		return err
+	}
+
+	doc.replaceItem(doc.withLeadingComments(item), nil)
+
+	return writeFilePreservingMode(path, doc.bytes())
+}
@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+func TestSplitListItems(t *testing.T) {
+	items := SplitListItems("- Waiting for database access\n  from the platform team\n- [ ] Flaky CI\n  - on the e2e suite\n")
+
+	expected := []string{"Waiting for database access from the platform team", "Flaky CI on the e2e suite"}
+	if len(items) != len(expected) {
+		t.Fatalf("Expected %d items, but got %d: %q", len(expected), len(items), items)
+	}
+	for i, item := range items {
+		if item != expected[i] {
+			t.Errorf("Expected item %d to be %q, but got %q", i, expected[i], item)
+		}
+	}
+}
+
+func TestTrackBlockers(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 7, d, 9, 0, 0, 0, time.UTC) }
+
+	entries := []pkg.DSUReport{
+		{ID: "3", Datetime: day(3), Blockers: "- Still waiting for the database access\n"},
+		{ID: "1", Datetime: day(1), Blockers: "- None\n"},
+		{ID: "2", Datetime: day(2), Blockers: "- Waiting for database access\n- Flaky CI pipeline\n"},
+		{ID: "4", Datetime: day(4), Blockers: "- Waiting on database access from platform\n"},
+	}
+
+	blockers := TrackBlockers(entries, DefaultBlockerSimilarity)
+	if len(blockers) != 2 {
+		t.Fatalf("Expected 2 blockers, but got %d: %+v", len(blockers), blockers)
+	}
+
+	database := blockers[0]
+	if !database.Open || database.FirstSeenID != "2" || database.Occurrences != 3 {
+		t.Errorf("Expected the database blocker to be open since entry 2 over 3 DSUs, but got %+v", database)
+	}
+	if days := database.Days(day(5)); days != 3 {
+		t.Errorf("Expected the database blocker to be open for 3 days, but got %d", days)
+	}
+
+	ci := blockers[1]
+	if ci.Open || ci.LastSeenID != "2" {
+		t.Errorf("Expected the CI blocker to be resolved after entry 2, but got %+v", ci)
+	}
+}
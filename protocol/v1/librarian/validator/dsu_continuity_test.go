@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+func TestCheckContinuity(t *testing.T) {
+	entries := []pkg.DSUReport{
+		{
+			ID:            "2",
+			Datetime:      time.Date(2024, 7, 2, 9, 0, 0, 0, time.UTC),
+			DoneYesterday: "- Wrote the database migration\n- Fixed the login redirect bug\n",
+			DoingToday:    "- Review pull requests\n",
+		},
+		{
+			ID:         "1",
+			Datetime:   time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC),
+			DoingToday: "- Write the database migration\n- Update the onboarding docs\n",
+		},
+	}
+
+	report := CheckContinuity(entries, DefaultContinuitySimilarity)
+
+	if len(report.Transitions) != 1 {
+		t.Fatalf("Expected 1 transition, but got %d", len(report.Transitions))
+	}
+
+	transition := report.Transitions[0]
+	if transition.FromID != "1" || transition.ToID != "2" {
+		t.Errorf("Expected a transition from 1 to 2, but got %s to %s", transition.FromID, transition.ToID)
+	}
+	if len(transition.Dropped) != 1 || transition.Dropped[0] != "Update the onboarding docs" {
+		t.Errorf("Expected the docs update to be dropped, but got %q", transition.Dropped)
+	}
+	if len(transition.Unplanned) != 1 || transition.Unplanned[0] != "Fixed the login redirect bug" {
+		t.Errorf("Expected the bug fix to be unplanned, but got %q", transition.Unplanned)
+	}
+	if report.Planned != 2 || report.Completed != 1 || report.Accuracy() != 0.5 {
+		t.Errorf("Expected 1 of 2 planned tasks to be completed, but got %d of %d", report.Completed, report.Planned)
+	}
+}
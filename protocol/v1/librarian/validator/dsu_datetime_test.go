@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+func TestDatetimeParser(t *testing.T) {
+	manila, err := time.LoadLocation("Asia/Manila")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %s", err)
+	}
+
+	cases := []struct {
+		name     string
+		config   pkg.DatetimeConfig
+		value    string
+		expected time.Time
+	}{
+		{"RFC 3339", pkg.DatetimeConfig{Strict: true}, "2024-07-03T09:00:00+08:00", time.Date(2024, 7, 3, 1, 0, 0, 0, time.UTC)},
+		{"date only in UTC", pkg.DatetimeConfig{}, "2024-07-03", time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC)},
+		{"date only in timezone", pkg.DatetimeConfig{Timezone: "Asia/Manila"}, "2024-07-03", time.Date(2024, 7, 3, 0, 0, 0, 0, manila)},
+		{"day first", pkg.DatetimeConfig{Strict: true, DateOrder: pkg.DateOrderDayFirst}, "03/07/2024", time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC)},
+		{"month first", pkg.DatetimeConfig{Strict: true, DateOrder: pkg.DateOrderMonthFirst}, "07/03/2024", time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC)},
+		{"unambiguous", pkg.DatetimeConfig{Strict: true}, "25/07/2024", time.Date(2024, 7, 25, 0, 0, 0, 0, time.UTC)},
+		{"layout", pkg.DatetimeConfig{Strict: true, Timezone: "Asia/Manila", Layouts: []string{"2006-01-02 15:04"}}, "2024-07-03 09:00", time.Date(2024, 7, 3, 9, 0, 0, 0, manila)},
+	}
+
+	for _, tc := range cases {
+		parser, err := pkg.NewDatetimeParser(tc.config)
+		if err != nil {
+			t.Fatalf("%s: NewDatetimeParser failed: %s", tc.name, err)
+		}
+		parsed, err := parser.Parse(tc.value)
+		if err != nil {
+			t.Errorf("%s: Parse failed: %s", tc.name, err)
+			continue
+		}
+		if !parsed.Equal(tc.expected) {
+			t.Errorf("%s: expected %s, but got %s", tc.name, tc.expected, parsed)
+		}
+	}
+}
+
+func TestDatetimeParserStrict(t *testing.T) {
+	parser, err := pkg.NewDatetimeParser(pkg.DatetimeConfig{Strict: true})
+	if err != nil {
+		t.Fatalf("NewDatetimeParser failed: %s", err)
+	}
+
+	_, err = parser.Parse("03/07/2024")
+	var ambiguous *pkg.AmbiguousDatetimeError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("Expected an ambiguous datetime error, but got %v", err)
+	}
+	if ambiguous.MonthFirst.Month() != time.March || ambiguous.DayFirst.Month() != time.July {
+		t.Errorf("Expected March 7 and July 3, but got %s and %s", ambiguous.MonthFirst, ambiguous.DayFirst)
+	}
+
+	parser, _ = pkg.NewDatetimeParser(pkg.DatetimeConfig{Strict: true, Layouts: []string{time.RFC3339}})
+	if _, err := parser.Parse("2024-07-03"); err == nil {
+		t.Error("Expected an error for a datetime matching none of the allowed layouts")
+	}
+
+	if _, err := pkg.NewDatetimeParser(pkg.DatetimeConfig{DateOrder: "ydm"}); err == nil {
+		t.Error("Expected an error for an invalid date order")
+	}
+}
+
+const mockAmbiguousDSUFile = `tomegg:
+  type: training
+  version: 0.1.0
+  definition: https://protocol.tome.gg/training/0.1.0
+
+meta:
+  format:
+    type: dsu
+    version: 0.1.0
+    definition: https://protocol.tome.gg/formats/dsu/0.1.0
+
+content:
+  - id: e1
+    datetime: 2024-07-01
+    done_yesterday: |
+      - Task A
+    doing_today: |
+      - Task B
+  - id: e2
+    datetime: 03/07/2024
+    done_yesterday: |
+      - Task B
+    doing_today: |
+      - Task C
+`
+
+func TestGetDSUFilesDatetimeConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "training", "dsu-reports.yaml")
+	writeMockFile(t, path, mockAmbiguousDSUFile)
+
+	strict := pkg.NewValidationPlan(nil, []*pkg.File{{Filepath: path}})
+	strict.Config.Datetime = pkg.DatetimeConfig{Strict: true}
+	dayFirst := pkg.NewValidationPlan(nil, []*pkg.File{{Filepath: path}})
+	dayFirst.Config.Datetime = pkg.DatetimeConfig{Strict: true, DateOrder: pkg.DateOrderDayFirst}
+
+	// The ambiguous entry is kept undated in strict mode, with its error, so
+	// that it can still be reached and fixed.
+	files, err := GetDSUFiles(strict)
+	if err != nil {
+		t.Fatalf("GetDSUFiles failed: %s", err)
+	}
+	if len(files) != 1 || len(files[0].Definition.Content) != 2 {
+		t.Fatalf("Expected both entries to be read in strict mode, but got %+v", files)
+	}
+	if e1 := files[0].Definition.Content[0]; e1.DatetimeErr != nil || e1.Datetime.IsZero() {
+		t.Errorf("Expected e1 to be dated, but got %+v", e1)
+	}
+	if e2 := files[0].Definition.Content[1]; e2.DatetimeErr == nil || !e2.Datetime.IsZero() {
+		t.Errorf("Expected e2 to be undated with its error, but got %+v", e2)
+	}
+
+	// Each plan parses with its own datetime settings.
+	files, err = GetDSUFiles(dayFirst)
+	if err != nil {
+		t.Fatalf("GetDSUFiles failed: %s", err)
+	}
+	if len(files) != 1 || len(files[0].Definition.Content) != 2 || files[0].Definition.Content[1].Datetime.Month() != time.July {
+		t.Fatalf("Expected e2 to be read as July 3, but got %+v", files)
+	}
+
+	err = NewDSUValidator(strict).File(&pkg.File{Filepath: path})
+	if err == nil || !strings.Contains(err.Error(), "entry e2") {
+		t.Errorf("Expected the validator to report entry e2, but got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package validator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	"gopkg.in/yaml.v2"
+)
+
+func TestResolveFieldDefinitionsWithSchema(t *testing.T) {
+	root := t.TempDir()
+	schema := "fields:\n  - name: mood\n    type: string\n  - name: hours_focused\n    type: int\n"
+	writeMockFile(t, filepath.Join(root, "schemas", "dsu.yaml"), schema)
+
+	fields, err := ResolveFieldDefinitions(root, []pkg.FieldDefinition{
+		{Name: "mood", Type: pkg.FieldTypeEnum, Values: []string{"great", "rough"}, Required: true},
+	}, "schemas/dsu.yaml")
+	if err != nil {
+		t.Fatalf("ResolveFieldDefinitions failed: %s", err)
+	}
+	if len(fields) != 2 || fields[0].Type != pkg.FieldTypeEnum || fields[1].Name != "hours_focused" {
+		t.Errorf("Expected the file's mood to override the schema's, but got %+v", fields)
+	}
+
+	if _, err := ResolveFieldDefinitions(root, []pkg.FieldDefinition{{Name: "blockers", Type: pkg.FieldTypeString}}, ""); err == nil {
+		t.Error("Expected an error for a field redefining a DSU field")
+	}
+	if _, err := ResolveFieldDefinitions(root, []pkg.FieldDefinition{{Name: "size", Type: "float"}}, ""); err == nil {
+		t.Error("Expected an error for an unsupported field type")
+	}
+}
+
+func TestValidateDSUFields(t *testing.T) {
+	fields := []pkg.FieldDefinition{
+		{Name: "mood", Type: pkg.FieldTypeEnum, Values: []string{"great", "rough"}, Required: true},
+		{Name: "hours_focused", Type: pkg.FieldTypeInt},
+		{Name: "links", Type: pkg.FieldTypeList},
+	}
+
+	entry := pkg.DSUReport{}
+	source := "id: x\ndatetime: 2024-07-01\ndoing_today: a\ndone_yesterday: b\nmood: great\nhours_focused: 5\nlinks: [a, b]\nenergy: high\n"
+	if err := yaml.Unmarshal([]byte(source), &entry); err != nil {
+		t.Fatalf("failed to parse entry: %s", err)
+	}
+
+	undeclared, err := ValidateDSUFields(fields, entry)
+	if err != nil {
+		t.Fatalf("Expected a valid entry, but got %s", err)
+	}
+	if len(undeclared) != 1 || undeclared[0] != "energy" {
+		t.Errorf("Expected energy to be undeclared, but got %v", undeclared)
+	}
+
+	cases := map[string]interface{}{
+		"mood":          "meh",
+		"hours_focused": "five",
+		"links":         "a",
+	}
+	for name, value := range cases {
+		invalid := pkg.DSUReport{ID: "x", Fields: map[string]interface{}{"mood": "great"}}
+		invalid.Fields[name] = value
+		if _, err := ValidateDSUFields(fields, invalid); err == nil {
+			t.Errorf("Expected an error for %s: %v", name, value)
+		}
+	}
+
+	if _, err := ValidateDSUFields(fields, pkg.DSUReport{ID: "x"}); err == nil {
+		t.Error("Expected an error for a missing required field")
+	}
+}
+
+func TestRenderDSUEntryWithCustomFields(t *testing.T) {
+	entry := pkg.DSUReport{
+		ID:          "x",
+		DatetimeRaw: "2024-07-01",
+		DoingToday:  "- Deploy\n",
+		Fields: map[string]interface{}{
+			"mood":  "great",
+			"links": []interface{}{"https://example.com", "PR #12"},
+			"notes": "first line\nsecond line\n",
+		},
+	}
+
+	rendered := RenderDSUEntry(entry)
+	if !strings.Contains(rendered, "mood: great\n") || !strings.Contains(rendered, "notes: |\n  first line\n") {
+		t.Errorf("Expected the custom fields to be rendered, but got:\n%s", rendered)
+	}
+
+	parsed := pkg.DSUReport{}
+	if err := yaml.Unmarshal([]byte(rendered), &parsed); err != nil {
+		t.Fatalf("failed to parse rendered entry: %s", err)
+	}
+	if pkg.FormatFieldValue(parsed.Fields["links"]) != "https://example.com, PR #12" || parsed.Fields["notes"] != "first line\nsecond line\n" {
+		t.Errorf("Expected the custom fields to round-trip, but got %v", parsed.Fields)
+	}
+}
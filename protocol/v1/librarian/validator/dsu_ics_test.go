@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+func TestWriteICS(t *testing.T) {
+	entries := []pkg.DSUReport{
+		{
+			ID:         "385d9c24-be5c-5032-a163-7ddab2d35a78",
+			Datetime:   time.Date(2024, 7, 1, 9, 0, 0, 0, time.FixedZone("PHT", 8*60*60)),
+			DoingToday: "- Write the migration, then deploy\n- " + strings.Repeat("Review the pull request ", 4) + "\n",
+			Blockers:   "- None\n",
+			FileTags:   []string{"daily_stand_up"},
+		},
+	}
+
+	var b strings.Builder
+	err := WriteICS(&b, entries, ICSOptions{
+		MissingEvaluations: entries,
+		Stamp:              time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("WriteICS failed: %s", err)
+	}
+	ics := b.String()
+
+	for _, expected := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:385d9c24-be5c-5032-a163-7ddab2d35a78@tome.gg\r\n",
+		"DTSTART;VALUE=DATE:20240701\r\nDTEND;VALUE=DATE:20240702\r\n",
+		"SUMMARY:DSU: Write the migration\\, then deploy\\; Review the pull",
+		"CATEGORIES:daily_stand_up\r\n",
+		"BEGIN:VTODO\r\n",
+		"DUE;VALUE=DATE:20240701\r\n",
+		"RELATED-TO:385d9c24-be5c-5032-a163-7ddab2d35a78@tome.gg\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, expected) {
+			t.Errorf("Expected the calendar to contain %q", expected)
+		}
+	}
+
+	if strings.Contains(ics, "Blockers:") {
+		t.Error("Expected placeholder blockers to be left out of the description")
+	}
+
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Expected lines to be folded at 75 octets, but got %q", line)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package validator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImportDSUsFromCSV(t *testing.T) {
+	path := writeMockFile(t, filepath.Join(t.TempDir(), "dsus.csv"), "date,yesterday,today,blockers,tags\n"+
+		"2024-07-02,- Wrote the migration,- Deploy,,\n"+
+		"2024-07-01,- Set up the repo,\"- Write the migration\n- Review the PR\",None,project_x;backend\n")
+
+	entries, _, err := ImportDSUs(ImportFromCSV, path, ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportDSUs failed: %s", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, but got %d", len(entries))
+	}
+	if entries[0].DatetimeRaw != "2024-07-01" || entries[0].DoingToday != "- Write the migration\n- Review the PR\n" {
+		t.Errorf("Expected entries sorted by date with their fields, but got %+v", entries[0])
+	}
+	if len(entries[0].Tags) != 2 || entries[0].Tags[1] != "backend" {
+		t.Errorf("Expected the tags project_x and backend, but got %v", entries[0].Tags)
+	}
+
+	again, _, _ := ImportDSUs(ImportFromCSV, path, ImportOptions{})
+	if entries[0].ID == "" || again[0].ID != entries[0].ID || entries[0].ID == entries[1].ID {
+		t.Errorf("Expected distinct IDs stable across imports, but got %s, %s and %s", entries[0].ID, again[0].ID, entries[1].ID)
+	}
+}
+
+func TestImportDSUsLocation(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := writeMockFile(t, filepath.Join(dir, "dsus.csv"), "datetime,yesterday,today\n"+
+		"2024-07-01,- Set up the repo,- Write the migration\n"+
+		"2024-07-02T09:00:00+08:00,- Wrote the migration,- Deploy\n")
+	markdownPath := writeMockFile(t, filepath.Join(dir, "2024-07-03.md"), "## Yesterday\n- Deployed\n## Today\n- Write docs\n")
+	loc := time.FixedZone("EST", -5*60*60)
+
+	entries, _, err := ImportDSUs(ImportFromCSV, csvPath, ImportOptions{Location: loc})
+	if err != nil {
+		t.Fatalf("ImportDSUs failed: %s", err)
+	}
+	if expected := time.Date(2024, 7, 1, 0, 0, 0, 0, loc); !entries[0].Datetime.Equal(expected) {
+		t.Errorf("Expected a CSV date without an offset to be read as %s, but got %s", expected, entries[0].Datetime)
+	}
+	if expected := time.Date(2024, 7, 2, 1, 0, 0, 0, time.UTC); !entries[1].Datetime.Equal(expected) {
+		t.Errorf("Expected a CSV datetime with an offset to keep it, but got %s", entries[1].Datetime)
+	}
+
+	entries, _, err = ImportDSUs(ImportFromMarkdown, markdownPath, ImportOptions{Location: loc})
+	if err != nil {
+		t.Fatalf("ImportDSUs failed: %s", err)
+	}
+	if expected := time.Date(2024, 7, 3, 0, 0, 0, 0, loc); len(entries) != 1 || !entries[0].Datetime.Equal(expected) {
+		t.Errorf("Expected a Markdown date to be read as %s, but got %+v", expected, entries)
+	}
+}
+
+func TestImportDSUsFromMarkdown(t *testing.T) {
+	dir := t.TempDir()
+	writeMockFile(t, filepath.Join(dir, "2024-07-03.md"), "# Daily note\n\n## Yesterday\n- Deployed\n\n## Today\n- Write docs\n\n## Journal\nNot part of the DSU\n")
+	writeMockFile(t, filepath.Join(dir, "week.md"), "# 2024-07-04\n### Yesterday:\n- Wrote docs\n### Today\n- Refactor\n### Blockers\n- Waiting on CI access\n")
+
+	entries, _, err := ImportDSUs(ImportFromMarkdown, dir, ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportDSUs failed: %s", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, but got %d", len(entries))
+	}
+	if entries[0].DatetimeRaw != "2024-07-03" || entries[0].DoingToday != "- Write docs\n" {
+		t.Errorf("Expected the date from the file name and the journal to be left out, but got %+v", entries[0])
+	}
+	if entries[1].DatetimeRaw != "2024-07-04" || entries[1].Blockers != "- Waiting on CI access\n" {
+		t.Errorf("Expected the date from the heading, but got %+v", entries[1])
+	}
+}
+
+func TestImportDSUsFromSlackJSON(t *testing.T) {
+	path := writeMockFile(t, filepath.Join(t.TempDir(), "2024-07-08.json"), `[
+  {"type": "message", "user": "U1", "text": "*Yesterday:* tests\n*Today:*\n• Fix <https://example.com/1|flaky test>\n*Blockers:* none", "ts": "1720422000.000100", "user_profile": {"real_name": "Jo"}},
+  {"type": "message", "user": "U2", "text": "*Yesterday:* reviews\n*Today:* release", "ts": "1720422100.000100"},
+  {"type": "message", "user": "U1", "text": "today I'm out", "ts": "1720422200.000100"}
+]`)
+
+	entries, _, err := ImportDSUs(ImportFromSlackJSON, path, ImportOptions{SlackUser: "jo"})
+	if err != nil {
+		t.Fatalf("ImportDSUs failed: %s", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, but got %d", len(entries))
+	}
+	if entries[0].DatetimeRaw != "2024-07-08T07:00:00Z" || entries[0].DoneYesterday != "tests\n" || entries[0].DoingToday != "- Fix [flaky test](https://example.com/1)\n" {
+		t.Errorf("Unexpected entry %+v", entries[0])
+	}
+}
+
+func TestImportDSUsRejectsInvalidEntries(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := writeMockFile(t, filepath.Join(dir, "dsus.csv"), "date,yesterday,today\n"+
+		"2024-07-01,- Set up the repo,- Write the migration\n"+
+		"2024-07-02,,- Deploy\n"+
+		"someday,- Deployed,- Rest\n"+
+		"2024-07-04,- Rested,- Write docs\n")
+
+	entries, rejected, err := ImportDSUs(ImportFromCSV, csvPath, ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportDSUs failed: %s", err)
+	}
+	if len(entries) != 2 || entries[0].DatetimeRaw != "2024-07-01" || entries[1].DatetimeRaw != "2024-07-04" {
+		t.Errorf("Expected the valid rows to be imported, but got %+v", entries)
+	}
+	if len(rejected) != 2 || rejected[0].Source != csvPath+":3" || rejected[1].Source != csvPath+":4" {
+		t.Fatalf("Expected rows 3 and 4 to be rejected, but got %v", rejected)
+	}
+	if !strings.Contains(rejected[0].Error(), "done_yesterday") {
+		t.Errorf("Expected the missing done_yesterday to be reported, but got %s", rejected[0])
+	}
+
+	markdownPath := writeMockFile(t, filepath.Join(dir, "notes.md"), "## Yesterday\n- Deployed\n## Today\n- Write docs\n")
+	entries, rejected, err = ImportDSUs(ImportFromMarkdown, markdownPath, ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportDSUs failed: %s", err)
+	}
+	if len(entries) != 0 || len(rejected) != 1 || rejected[0].Source != markdownPath+":1" {
+		t.Errorf("Expected the undated note to be rejected, but got %+v and %v", entries, rejected)
+	}
+}
@@ -0,0 +1,152 @@
+package validator
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+const mockListDSUFile = `tomegg:
+  type: training
+  version: 0.1.0
+  definition: https://protocol.tome.gg/training/0.1.0
+
+meta:
+  tags: [daily_stand_up]
+  format:
+    type: dsu
+    version: 0.1.0
+    definition: https://protocol.tome.gg/formats/dsu/0.1.0
+
+content:
+  - id: e2
+    datetime: 2024-07-02
+    done_yesterday: |
+      - Wrote the migration
+    doing_today: |
+      - Review the Docker image
+    blockers: |
+      - Waiting for database access
+    tags: [project_x]
+  - id: e1
+    datetime: 2024-07-01
+    done_yesterday: |
+      - Set up the Docker compose file
+    doing_today: |
+      - Write the migration
+    hours: 6
+    links: [compose.yaml, Dockerfile]
+    review: {by: Ada, approved: true}
+  - id: e3
+    datetime: 2024-07-03
+    done_yesterday: |
+      - Reviewed the Docker image
+    doing_today: |
+      - Deploy
+    blockers: |
+      - None
+`
+
+const mockListEvaluationFile = `tomegg:
+  type: evaluations
+  version: 0.1.0
+  definition: https://protocol.tome.gg/evaluations/0.1.0
+
+meta:
+  evaluator:
+    name: Mentor
+  dimensions:
+    - alias: focus
+      name: focus
+      version: 0.1.0
+      definition: https://protocol.tome.gg/dimensions/focus/0.1.0
+
+evaluations:
+  - id: e1
+    measurements:
+      - dimension: focus
+        score: 3
+`
+
+func TestListDSUs(t *testing.T) {
+	root := t.TempDir()
+	dsuPath := filepath.Join(root, "training", "dsu-reports.yaml")
+	evaluationPath := filepath.Join(root, "evaluations", "mentor.yaml")
+	writeMockFile(t, dsuPath, mockListDSUFile)
+	writeMockFile(t, evaluationPath, mockListEvaluationFile)
+	plan := pkg.NewValidationPlan(nil, []*pkg.File{{Filepath: dsuPath}, {Filepath: evaluationPath}})
+
+	date := func(s string) time.Time {
+		d, _ := time.Parse(pkg.DateLayout, s)
+		return d
+	}
+
+	testCases := []struct {
+		name     string
+		since    time.Time
+		until    time.Time
+		tags     []string
+		expected []string
+	}{
+		{name: "all entries, by date", expected: []string{"e1", "e2", "e3"}},
+		{name: "since", since: date("2024-07-02"), expected: []string{"e2", "e3"}},
+		{name: "until, inclusive", until: date("2024-07-02"), expected: []string{"e1", "e2"}},
+		{name: "file tag", tags: []string{"daily_stand_up"}, expected: []string{"e1", "e2", "e3"}},
+		{name: "entry tag", tags: []string{"project_x"}, expected: []string{"e2"}},
+		{name: "unknown tag", tags: []string{"project_y"}, expected: []string{}},
+	}
+
+	for _, tc := range testCases {
+		listings, err := ListDSUs(plan, tc.since, tc.until, tc.tags)
+		if err != nil {
+			t.Fatalf("%s: ListDSUs failed: %s", tc.name, err)
+		}
+		ids := []string{}
+		for _, listing := range listings {
+			ids = append(ids, listing.ID)
+		}
+		if strings.Join(ids, ",") != strings.Join(tc.expected, ",") {
+			t.Errorf("%s: expected %v, but got %v", tc.name, tc.expected, ids)
+		}
+	}
+
+	listings, _ := ListDSUs(plan, time.Time{}, time.Time{}, nil)
+	first, second, third := listings[0], listings[1], listings[2]
+	if !first.Evaluated || strings.Join(first.EvaluatedBy, ",") != "Mentor" || second.Evaluated {
+		t.Errorf("Expected only e1 to be evaluated by Mentor, but got %v and %v", first.EvaluatedBy, second.EvaluatedBy)
+	}
+	if !second.HasBlockers || third.HasBlockers {
+		t.Error("Expected only e2 to have blockers, a None placeholder is not a blocker")
+	}
+	if strings.Join(second.Tags, ",") != "daily_stand_up,project_x" || first.File != dsuPath {
+		t.Errorf("Expected the effective tags and file of the entries, but got %v in %s", second.Tags, first.File)
+	}
+
+	// Custom fields keep their types in the JSON and YAML listings.
+	encoded, err := json.Marshal(first.Fields)
+	if err != nil {
+		t.Fatalf("failed to encode the fields of e1: %s", err)
+	}
+	if expected := `{"hours":6,"links":["compose.yaml","Dockerfile"],"review":{"approved":true,"by":"Ada"}}`; string(encoded) != expected {
+		t.Errorf("Expected the fields of e1 to be %s, but got %s", expected, encoded)
+	}
+}
+
+func TestFilterDSUFilesByTags(t *testing.T) {
+	files := mockSearchFiles()
+
+	filtered := FilterDSUFilesByTags(files, []string{"Project_X"})
+	if len(filtered) != 1 || len(filtered[0].Definition.Content) != 1 || filtered[0].Definition.Content[0].Tags[0] != "project_x" {
+		t.Fatalf("Expected only the project_x entry to be kept, but got %+v", filtered)
+	}
+	if len(files[0].Definition.Content) != 2 {
+		t.Error("Expected the original files to be left untouched")
+	}
+	if unfiltered := FilterDSUFilesByTags(files, nil); len(unfiltered[0].Definition.Content) != 2 {
+		t.Error("Expected every entry to be kept without tags")
+	}
+}
@@ -0,0 +1,117 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRotationPeriodFilenames(t *testing.T) {
+	date := time.Date(2024, 8, 15, 0, 0, 0, 0, time.UTC)
+
+	cases := map[RotationPeriod]string{
+		RotateByQuarter: "dsu-reports-q3-2024.yaml",
+		RotateByMonth:   "dsu-reports-2024-08.yaml",
+		RotateByYear:    "dsu-reports-2024.yaml",
+	}
+
+	for period, expected := range cases {
+		filename := period.Filename("dsu-reports", date)
+		if filename != expected {
+			t.Errorf("Expected %s filename %s, but got %s", period, expected, filename)
+		}
+
+		detected, base, ok := DetectRotationPeriod("/mock/repo/training/" + filename)
+		if !ok || detected != period || base != "dsu-reports" {
+			t.Errorf("Expected %s to be detected as a %s file, but got %s (%s, %v)", filename, period, detected, base, ok)
+		}
+	}
+
+	if _, _, ok := DetectRotationPeriod("/mock/repo/training/dsu-reports.yaml"); ok {
+		t.Error("Expected dsu-reports.yaml not to be detected as a period file")
+	}
+}
+
+func TestRotateDSUFile(t *testing.T) {
+	path := writeMockFile(t, filepath.Join(t.TempDir(), "dsu-reports.yaml"), mockDSUFile+`  - id: a7fd6a39-b857-585f-9233-85cec2027477
+    datetime: 2023-04-03
+    done_yesterday: |
+      - Task C
+    doing_today: |
+      - Task D
+`)
+
+	_, definition := readMockDSUFile(t, path)
+	moves, err := RotateDSUFile(DSUFile{Filepath: path, Definition: definition}, RotateByQuarter, false)
+	if err != nil {
+		t.Fatalf("RotateDSUFile failed: %s", err)
+	}
+
+	if len(moves) != 2 {
+		t.Fatalf("Expected 2 moved entries, but got %d", len(moves))
+	}
+
+	_, rotated := readMockDSUFile(t, path)
+	if len(rotated.Content) != 0 {
+		t.Errorf("Expected the source file to be empty, but found %d entries", len(rotated.Content))
+	}
+
+	for _, expected := range []struct {
+		filename string
+		id       string
+	}{
+		{"dsu-reports-q1-2023.yaml", "385d9c24-be5c-5032-a163-7ddab2d35a78"},
+		{"dsu-reports-q2-2023.yaml", "a7fd6a39-b857-585f-9233-85cec2027477"},
+	} {
+		target := filepath.Join(filepath.Dir(path), expected.filename)
+		if _, err := os.Stat(target); err != nil {
+			t.Errorf("Expected period file %s to be created", expected.filename)
+			continue
+		}
+
+		_, result := readMockDSUFile(t, target)
+		if result.Tomegg.Type != "training" || result.Meta.Format.Type != "dsu" {
+			t.Errorf("Expected %s to have the training header copied over", expected.filename)
+		}
+		if len(result.Content) != 1 || result.Content[0].ID != expected.id {
+			t.Errorf("Expected %s to hold entry %s, but got %+v", expected.filename, expected.id, result.Content)
+		}
+	}
+}
+
+func TestCreateDSUFileFrom(t *testing.T) {
+	dir := t.TempDir()
+	source := writeMockFile(t, filepath.Join(dir, "dsu-reports.yaml"), `content:
+  - id: e1
+    datetime: 2023-03-01
+    done_yesterday: |
+      - Task A
+    doing_today: |
+      - Task B
+
+# Meta after the content
+meta:
+  format:
+    type: dsu
+    version: 0.1.0
+  tags: [project_x]
+
+tomegg:
+  type: training
+  version: 0.1.0
+`)
+
+	target := filepath.Join(dir, "dsu-reports-2023-03.yaml")
+	if err := CreateDSUFileFrom(target, source); err != nil {
+		t.Fatalf("CreateDSUFileFrom failed: %s", err)
+	}
+
+	_, created := readMockDSUFile(t, target)
+	if created.Tomegg.Type != "training" || created.Meta.Format.Type != "dsu" || len(created.Meta.Tags) != 1 {
+		t.Errorf("Expected the header of the source file, even after its content, but got %+v", created)
+	}
+	if len(created.Content) != 0 {
+		t.Errorf("Expected no entries, but got %+v", created.Content)
+	}
+}
@@ -0,0 +1,107 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+func mockSearchFiles() []DSUFile {
+	file := DSUFile{Filepath: "/mock/repo/training/dsu-reports.yaml"}
+	file.Definition.Content = []pkg.DSUReport{
+		{
+			ID:            "385d9c24-be5c-5032-a163-7ddab2d35a78",
+			Datetime:      time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC),
+			DoneYesterday: "- Set up the Docker compose file\n",
+			DoingToday:    "- Write the migration\n",
+			Blockers:      "- None\n",
+			FileTags:      []string{"daily_stand_up"},
+			Tags:          []string{"project_x"},
+		},
+		{
+			ID:            "a7fd6a39-b857-585f-9233-85cec2027477",
+			Datetime:      time.Date(2024, 7, 2, 9, 0, 0, 0, time.UTC),
+			DoneYesterday: "- Wrote the migration\n",
+			DoingToday:    "- Review the Docker image\n",
+			Blockers:      "- Waiting for database access\n",
+			FileTags:      []string{"daily_stand_up"},
+		},
+	}
+	return []DSUFile{file}
+}
+
+func TestSearchDSUs(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    DSUQuery
+		expected []string
+	}{
+		{"all terms", DSUQuery{Terms: []string{"docker", "migration"}}, []string{"385d9c24-be5c-5032-a163-7ddab2d35a78", "a7fd6a39-b857-585f-9233-85cec2027477"}},
+		{"field", DSUQuery{Terms: []string{"docker"}, Fields: []string{"doing_today"}}, []string{"a7fd6a39-b857-585f-9233-85cec2027477"}},
+		{"since", DSUQuery{Terms: []string{"migration"}, Since: time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)}, []string{"a7fd6a39-b857-585f-9233-85cec2027477"}},
+		{"has blockers", DSUQuery{Terms: []string{"docker"}, HasBlockers: true}, []string{"a7fd6a39-b857-585f-9233-85cec2027477"}},
+		{"entry tag", DSUQuery{Terms: []string{"docker"}, Tags: []string{"project_x"}}, []string{"385d9c24-be5c-5032-a163-7ddab2d35a78"}},
+		{"file and entry tags", DSUQuery{Terms: []string{"docker"}, Tags: []string{"daily_stand_up", "project_x"}}, []string{"385d9c24-be5c-5032-a163-7ddab2d35a78"}},
+		{"unknown tag", DSUQuery{Terms: []string{"docker"}, Tags: []string{"project_y"}}, []string{}},
+	}
+
+	for _, tc := range cases {
+		matches, err := SearchDSUs(mockSearchFiles(), tc.query)
+		if err != nil {
+			t.Fatalf("%s: SearchDSUs failed: %s", tc.name, err)
+		}
+		if len(matches) != len(tc.expected) {
+			t.Errorf("%s: expected %d matches, but got %d", tc.name, len(tc.expected), len(matches))
+			continue
+		}
+		for i, match := range matches {
+			if match.ID != tc.expected[i] {
+				t.Errorf("%s: expected match %d to be %s, but got %s", tc.name, i, tc.expected[i], match.ID)
+			}
+		}
+	}
+
+	if _, err := SearchDSUs(mockSearchFiles(), DSUQuery{Fields: []string{"mood"}}); err == nil {
+		t.Error("Expected an error for an unsupported field")
+	}
+}
+
+func TestIsBlockerPlaceholder(t *testing.T) {
+	for _, text := range []string{"", "- None", "None.", "* N/A\n", "1. nothing"} {
+		if !IsBlockerPlaceholder(text) {
+			t.Errorf("Expected %q to be a blocker placeholder", text)
+		}
+	}
+	if IsBlockerPlaceholder("- None\n- Waiting for database access\n") {
+		t.Error("Expected an actual blocker not to be a placeholder")
+	}
+}
+
+func TestTermRanges(t *testing.T) {
+	testCases := []struct {
+		text     string
+		terms    []string
+		expected []string
+	}{
+		{"café déploy", []string{"DÉPLOY"}, []string{"déploy"}},
+		{"Café au lait, CAFÉ noir", []string{"café"}, []string{"Café", "CAFÉ"}},
+		// The lowercase form of Ⱥ is longer than Ⱥ itself.
+		{"ȺȺȺȺ ab", []string{"ab"}, []string{"ab"}},
+		{"ȺȺȺȺ ab", []string{"ⱥⱥ"}, []string{"ȺȺȺȺ"}},
+		{"docker compose", []string{"docker", "ker com", " "}, []string{"docker com"}},
+		{"docker", []string{"", "podman"}, []string{}},
+	}
+
+	for _, tc := range testCases {
+		ranges := TermRanges(tc.text, tc.terms)
+		matched := []string{}
+		for _, r := range ranges {
+			matched = append(matched, tc.text[r[0]:r[1]])
+		}
+		if strings.Join(matched, "|") != strings.Join(tc.expected, "|") {
+			t.Errorf("%q: expected %q to be matched, but got %q", tc.text, tc.expected, matched)
+		}
+	}
+}
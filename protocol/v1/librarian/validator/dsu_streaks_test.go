@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+func mockCalendar(t *testing.T) *pkg.Calendar {
+	config := &pkg.TomeConfig{}
+	config.Calendar.Leave = []pkg.LeaveRange{
+		{From: "2024-07-10", To: "2024-07-11", Reason: "Vacation"},
+	}
+
+	calendar, err := pkg.LoadCalendar(config)
+	if err != nil {
+		t.Fatalf("failed to load calendar: %s", err)
+	}
+	return calendar
+}
+
+func mockDatedEntries(dates ...string) []pkg.DSUReport {
+	entries := []pkg.DSUReport{}
+	for _, date := range dates {
+		datetime, _ := time.Parse(pkg.DateLayout, date)
+		entries = append(entries, pkg.DSUReport{ID: date, Datetime: datetime})
+	}
+	return entries
+}
+
+func TestComputeStreaks(t *testing.T) {
+	calendar := mockCalendar(t)
+
+	// Mon 1 to Wed 3, a missed Thu 4, then Fri 5 to Tue 9, a leave on Wed 10
+	// and Thu 11, then Fri 12.
+	entries := mockDatedEntries(
+		"2024-07-01", "2024-07-02", "2024-07-03",
+		"2024-07-05", "2024-07-08", "2024-07-09",
+		"2024-07-12",
+	)
+
+	// Mon 15 has no DSU yet, which does not break the streak.
+	report := ComputeStreaks(entries, calendar, time.Date(2024, 7, 15, 10, 0, 0, 0, time.UTC))
+
+	if report.Current != 4 {
+		t.Errorf("Expected a current streak of 4, but got %d", report.Current)
+	}
+	if report.CurrentStart.Format(pkg.DateLayout) != "2024-07-05" {
+		t.Errorf("Expected the current streak to start on 2024-07-05, but got %s", report.CurrentStart.Format(pkg.DateLayout))
+	}
+	if report.Longest != 4 {
+		t.Errorf("Expected a longest streak of 4, but got %d", report.Longest)
+	}
+
+	// Once Mon 15 is over without a DSU, the streak is broken.
+	report = ComputeStreaks(entries, calendar, time.Date(2024, 7, 16, 10, 0, 0, 0, time.UTC))
+	if report.Current != 0 {
+		t.Errorf("Expected the current streak to be broken, but got %d", report.Current)
+	}
+}
+
+func TestFindGaps(t *testing.T) {
+	calendar := mockCalendar(t)
+	entries := mockDatedEntries("2024-07-01", "2024-07-02", "2024-07-05")
+
+	gaps := FindGaps(entries, calendar, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC))
+
+	expected := []string{"2024-07-03", "2024-07-04", "2024-07-08", "2024-07-09", "2024-07-12"}
+	if len(gaps) != len(expected) {
+		t.Fatalf("Expected %d gaps, but got %d: %v", len(expected), len(gaps), gaps)
+	}
+	for i, gap := range gaps {
+		if gap.Format(pkg.DateLayout) != expected[i] {
+			t.Errorf("Expected gap %d to be %s, but got %s", i, expected[i], gap.Format(pkg.DateLayout))
+		}
+	}
+}
+
+func TestLoadCalendarInvalidLeave(t *testing.T) {
+	config := &pkg.TomeConfig{}
+	config.Calendar.Leave = []pkg.LeaveRange{{From: "2024-07-11", To: "2024-07-10"}}
+
+	_, err := pkg.LoadCalendar(config)
+	if err == nil || !strings.Contains(err.Error(), "2024-07-11 to 2024-07-10") {
+		t.Errorf("Expected an error naming the leave range, but got %v", err)
+	}
+}
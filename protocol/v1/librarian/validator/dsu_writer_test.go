@@ -0,0 +1,167 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	"gopkg.in/yaml.v2"
+)
+
+const mockDSUFile = `# A comment the apprentice wrote
+tomegg:
+  type: training
+  version: 0.1.0
+  definition: https://protocol.tome.gg/training/0.1.0
+
+meta:
+  format:
+    type: dsu
+    version: 0.1.0
+    definition: https://protocol.tome.gg/formats/dsu/0.1.0
+
+content:
+  # First week
+  - id: 385d9c24-be5c-5032-a163-7ddab2d35a78
+    datetime: 2023-03-20
+    done_yesterday: |
+      - Task A
+    doing_today: |
+      - Task B
+`
+
+func readMockDSUFile(t *testing.T, path string) (string, pkg.TrainingDefinition[pkg.DSUReport]) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read mock DSU file: %s", err)
+	}
+
+	var result pkg.TrainingDefinition[pkg.DSUReport]
+	if err := yaml.Unmarshal(fileBytes, &result); err != nil {
+		t.Fatalf("written DSU file is not valid YAML: %s", err)
+	}
+	for i := range result.Content {
+		if err := result.Content[i].ParseDatetime(&pkg.DatetimeParser{}); err != nil {
+			t.Fatalf("written DSU entry has an invalid datetime: %s", err)
+		}
+	}
+
+	return string(fileBytes), result
+}
+
+func TestAppendDSUEntry(t *testing.T) {
+	path := writeMockFile(t, filepath.Join(t.TempDir(), "dsu-reports.yaml"), mockDSUFile)
+
+	entry := pkg.DSUReport{
+		ID:            "a7fd6a39-b857-585f-9233-85cec2027477",
+		DatetimeRaw:   "2023-03-21T09:00:00+08:00",
+		DoneYesterday: "- Task B\n",
+		DoingToday:    "- Task C\n  - Subtask\n",
+		Blockers:      "",
+	}
+
+	if err := AppendDSUEntry(path, entry); err != nil {
+		t.Fatalf("AppendDSUEntry failed: %s", err)
+	}
+
+	text, result := readMockDSUFile(t, path)
+
+	for _, comment := range []string{"# A comment the apprentice wrote", "# First week"} {
+		if !strings.Contains(text, comment) {
+			t.Errorf("Expected comment %q to be kept", comment)
+		}
+	}
+
+	if len(result.Content) != 2 {
+		t.Fatalf("Expected 2 entries, but found %d", len(result.Content))
+	}
+
+	appended := result.Content[1]
+	if appended.ID != entry.ID {
+		t.Errorf("Expected appended ID %s, but got %s", entry.ID, appended.ID)
+	}
+	if appended.DoingToday != entry.DoingToday {
+		t.Errorf("Expected doing_today %q, but got %q", entry.DoingToday, appended.DoingToday)
+	}
+	if appended.Datetime.IsZero() {
+		t.Error("Expected appended datetime to be parsed")
+	}
+}
+
+func TestAppendDSUEntryToEmptyContent(t *testing.T) {
+	path := writeMockFile(t, filepath.Join(t.TempDir(), "dsu-reports.yaml"), mockDSUFile[:strings.Index(mockDSUFile, "content:")]+"content: []\n")
+
+	entry := pkg.DSUReport{
+		ID:            "a7fd6a39-b857-585f-9233-85cec2027477",
+		DatetimeRaw:   "2023-03-21",
+		DoneYesterday: "- Task B",
+		DoingToday:    "- Task C",
+	}
+
+	if err := AppendDSUEntry(path, entry); err != nil {
+		t.Fatalf("AppendDSUEntry failed: %s", err)
+	}
+
+	_, result := readMockDSUFile(t, path)
+	if len(result.Content) != 1 || result.Content[0].DoneYesterday != entry.DoneYesterday {
+		t.Errorf("Expected the single appended entry, but got %+v", result.Content)
+	}
+}
+
+func TestReplaceAndRemoveDSUEntry(t *testing.T) {
+	path := writeMockFile(t, filepath.Join(t.TempDir(), "dsu-reports.yaml"), mockDSUFile+`  # Second week
+  - id: a7fd6a39-b857-585f-9233-85cec2027477
+    datetime: 2023-03-27
+    done_yesterday: |
+      - Task C
+    doing_today: |
+      - Task D
+# Trailing comment
+`)
+
+	edited := pkg.DSUReport{
+		ID:            "385d9c24-be5c-5032-a163-7ddab2d35a78",
+		DatetimeRaw:   "2023-03-20",
+		DoneYesterday: "- Task A\n",
+		DoingToday:    "- Task B, revised\n",
+	}
+
+	if err := ReplaceDSUEntry(path, edited.ID, edited); err != nil {
+		t.Fatalf("ReplaceDSUEntry failed: %s", err)
+	}
+
+	text, result := readMockDSUFile(t, path)
+	if len(result.Content) != 2 {
+		t.Fatalf("Expected 2 entries after replacing, but found %d", len(result.Content))
+	}
+	if result.Content[0].DoingToday != edited.DoingToday {
+		t.Errorf("Expected doing_today %q, but got %q", edited.DoingToday, result.Content[0].DoingToday)
+	}
+	if result.Content[1].DoingToday != "- Task D\n" {
+		t.Errorf("Expected the second entry to be untouched, but got %q", result.Content[1].DoingToday)
+	}
+	if !strings.Contains(text, "# Second week") {
+		t.Error("Expected the comment of the second entry to be kept")
+	}
+
+	if err := RemoveDSUEntry(path, "a7fd6a39-b857-585f-9233-85cec2027477"); err != nil {
+		t.Fatalf("RemoveDSUEntry failed: %s", err)
+	}
+
+	text, result = readMockDSUFile(t, path)
+	if len(result.Content) != 1 || result.Content[0].ID != edited.ID {
+		t.Errorf("Expected only entry %s to remain, but got %+v", edited.ID, result.Content)
+	}
+	if strings.Contains(text, "# Second week") {
+		t.Error("Expected the comment of the removed entry to be removed")
+	}
+	if !strings.Contains(text, "# Trailing comment") {
+		t.Error("Expected the trailing comment to be kept")
+	}
+
+	if err := RemoveDSUEntry(path, "missing"); err == nil {
+		t.Error("Expected an error when removing a missing entry")
+	}
+}
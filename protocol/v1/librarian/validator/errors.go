@@ -1,6 +1,11 @@
 package validator
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
 
 // ErrInvalidTrainingType ...
 var ErrInvalidTrainingType = fmt.Errorf("invalid training file type")
@@ -32,8 +37,20 @@ func ErrMismatchedFormatDefinition(formatType, expected, actual string) error {
 // ErrNoDimension ...
 var ErrNoDimension = fmt.Errorf("no dimension specified for evaluation")
 
-// ErrUnregisteredDimension ...
-var ErrUnregisteredDimension = fmt.Errorf("dimension not registered for evaluation")
+// ErrUndeclaredDimension ...
+func ErrUndeclaredDimension(id string, dimension string, file string) error {
+	return fmt.Errorf("dimension %s of the evaluation of training %s is not declared in the meta.dimensions of %s", dimension, id, file)
+}
+
+// ErrDuplicateDimensionAlias ...
+func ErrDuplicateDimensionAlias(alias string, file string) error {
+	return fmt.Errorf("dimension alias %s is declared more than once in %s", alias, file)
+}
+
+// ErrInconsistentDimensionAlias ...
+func ErrInconsistentDimensionAlias(alias string, first string, other string) error {
+	return fmt.Errorf("dimension alias %s resolves to %s, but to %s", alias, first, other)
+}
 
 // ErrNoMeasurements ...
 var ErrNoMeasurements = fmt.Errorf("no measurements found")
@@ -46,4 +63,105 @@ func ErrRequiredField(id string, field string) error {
 // ErrTrainingNotFound ...
 func ErrTrainingNotFound(id string) error {
 	return fmt.Errorf("specified training %s was not found", id)
-}
\ No newline at end of file
+}
+
+// ErrUnknownTag ...
+func ErrUnknownTag(id string, tag string) error {
+	return fmt.Errorf("tag %s of content entry %s is not in the tag vocabulary of tome.yaml", tag, id)
+}
+
+// ErrUnknownFileTag ...
+func ErrUnknownFileTag(tag string) error {
+	return fmt.Errorf("tag %s in meta.tags of the training file is not in the tag vocabulary of tome.yaml", tag)
+}
+
+// ErrInvalidFieldDefinition ...
+func ErrInvalidFieldDefinition(name string, reason string) error {
+	return fmt.Errorf("invalid custom field definition %s: %s", name, reason)
+}
+
+// ErrInvalidField ...
+func ErrInvalidField(id string, field string, reason string) error {
+	return fmt.Errorf("invalid field %s for content entry %s: %s", field, id, reason)
+}
+
+// ErrMissingEvaluator ...
+func ErrMissingEvaluator(path string) error {
+	return fmt.Errorf("evaluation file %s must declare its meta.evaluator, as it is not a self evaluation", path)
+}
+
+// ErrInvalidEvaluator ...
+func ErrInvalidEvaluator(field string, reason string) error {
+	return fmt.Errorf("invalid evaluator %s: %s", field, reason)
+}
+
+// ErrUnsupportedSubtype ...
+func ErrUnsupportedSubtype(subtype string) error {
+	return fmt.Errorf("unsupported evaluation subtype %q", subtype)
+}
+
+// ErrSubtypeDimension ...
+func ErrSubtypeDimension(subtype string, dimension string, reason string) error {
+	if subtype == "" {
+		subtype = "standard"
+	}
+	return fmt.Errorf("dimension %s in %s evaluations: %s", dimension, subtype, reason)
+}
+
+// ErrDuplicateEvaluation ...
+func ErrDuplicateEvaluation(id string, reason string) error {
+	return fmt.Errorf("duplicate evaluation of training %s: %s", id, reason)
+}
+
+// ErrInvalidScale ...
+func ErrInvalidScale(dimension string, reason string) error {
+	return fmt.Errorf("invalid scale for dimension %s: %s", dimension, reason)
+}
+
+// ErrUnknownDimension ...
+func ErrUnknownDimension(name string) error {
+	return fmt.Errorf("dimension %s is not in the dimension catalogue", name)
+}
+
+// ErrUnknownDimensionVersion ...
+func ErrUnknownDimensionVersion(name string, version string) error {
+	return fmt.Errorf("version %s of dimension %s is not in the dimension catalogue", version, name)
+}
+
+// ErrUnsupportedMeasurementKind ...
+func ErrUnsupportedMeasurementKind(dimension string, kind string) error {
+	return fmt.Errorf("unsupported measurement kind %q for dimension %s", kind, dimension)
+}
+
+// ErrInvalidDimensionKind ...
+func ErrInvalidDimensionKind(dimension string, kind string, reason string) error {
+	return fmt.Errorf("invalid %s dimension %s: %s", kind, dimension, reason)
+}
+
+// ErrInvalidMeasurement ...
+func ErrInvalidMeasurement(id string, dimension string, reason string) error {
+	return fmt.Errorf("invalid %s measurement for training %s: %s", dimension, id, reason)
+}
+
+// ErrInvalidSignature ...
+func ErrInvalidSignature(signature pkg.Signature, reason string) error {
+	return fmt.Errorf("invalid signature of %s by key %s: %s", SignedContent(signature), KeyFingerprint(signature.PublicKey), reason)
+}
+
+// ErrSignatureMismatch ...
+func ErrSignatureMismatch(signature pkg.Signature, reason string) error {
+	return fmt.Errorf("signature of %s by key %s does not match: %s", SignedContent(signature), KeyFingerprint(signature.PublicKey), reason)
+}
+
+// ErrUntrustedSignature ...
+func ErrUntrustedSignature(signature pkg.Signature) error {
+	return fmt.Errorf("signature of %s is by untrusted key %s", SignedContent(signature), KeyFingerprint(signature.PublicKey))
+}
+
+// ErrUnsignedEvaluations ...
+func ErrUnsignedEvaluations(evaluator string, ids []string) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("evaluations by %s are not signed with a trusted key", evaluator)
+	}
+	return fmt.Errorf("evaluations by %s of %s are not signed with a trusted key", evaluator, strings.Join(ids, ", "))
+}
@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+// evaluationSubtype validates the semantics specific to an evaluation subtype,
+// after the checks shared by every evaluation file.
+type evaluationSubtype func(path string, definition pkg.EvaluationDefinition[pkg.Measurement]) error
+
+// evaluationSubtypes maps the tomegg.subtype of an evaluation file to its validator.
+var evaluationSubtypes = map[string]evaluationSubtype{
+	pkg.EvaluationSubtypeStandard: validateStandardEvaluation,
+	pkg.EvaluationSubtypeMeta:     validateMetaEvaluation,
+}
+
+// IsEvaluationSubtype returns true if the tomegg.subtype is a supported evaluation subtype.
+func IsEvaluationSubtype(subtype string) bool {
+	_, ok := evaluationSubtypes[subtype]
+	return ok
+}
+
+// validateStandardEvaluation checks evaluations of the apprentice's training:
+// they name their evaluator unless they are self evaluations, and leave the
+// rating of the mentor's teaching to meta evaluations.
+func validateStandardEvaluation(path string, definition pkg.EvaluationDefinition[pkg.Measurement]) error {
+	if definition.Meta.Evaluator == nil && !IsSelfEvaluation(path) {
+		return ErrMissingEvaluator(path)
+	}
+
+	for _, dimension := range definition.Meta.Dimensions {
+		if dimension.Name == pkg.TeachingDimension {
+			return ErrSubtypeDimension(pkg.EvaluationSubtypeStandard, dimension.Name, "the mentor's teaching is rated by meta evaluations")
+		}
+	}
+
+	return nil
+}
+
+// validateMetaEvaluation checks evaluations of the mentor's teaching: every
+// record rates the teaching of a single training entry.
+func validateMetaEvaluation(path string, definition pkg.EvaluationDefinition[pkg.Measurement]) error {
+	if definition.Meta.Mentor != nil {
+		if err := ValidateEvaluator(*definition.Meta.Mentor); err != nil {
+			return err
+		}
+	}
+
+	declared := false
+	for _, dimension := range definition.Meta.Dimensions {
+		if dimension.Name == pkg.TeachingDimension {
+			declared = true
+		}
+	}
+	if !declared {
+		return ErrSubtypeDimension(pkg.EvaluationSubtypeMeta, pkg.TeachingDimension, "meta evaluations must declare it")
+	}
+
+	file := EvaluationFile{Filepath: path, Definition: definition}
+	rated := map[string]bool{}
+	for _, record := range definition.Evaluations {
+		if rated[record.ID] {
+			return ErrDuplicateEvaluation(record.ID, "the teaching of a training entry is rated once")
+		}
+		rated[record.ID] = true
+
+		teaching := false
+		for _, measurement := range record.Measurements {
+			if file.DimensionName(measurement.Dimension) == pkg.TeachingDimension {
+				teaching = true
+			}
+		}
+		if !teaching {
+			return ErrRequiredField(record.ID, pkg.TeachingDimension)
+		}
+	}
+
+	return nil
+}
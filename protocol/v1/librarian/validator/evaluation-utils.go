@@ -0,0 +1,144 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	"gopkg.in/yaml.v2"
+)
+
+// EvaluationFile pairs an evaluation file with its parsed definition.
+type EvaluationFile struct {
+	Filepath   string
+	Definition pkg.EvaluationDefinition[pkg.Measurement]
+}
+
+// Dimension returns the dimension declared in the file's meta under the alias
+// or name, nil when there is none.
+func (f EvaluationFile) Dimension(alias string) *pkg.DimensionDeclaration {
+	for i, dimension := range f.Definition.Meta.Dimensions {
+		if dimension.Alias == alias || dimension.Name == alias {
+			return &f.Definition.Meta.Dimensions[i]
+		}
+	}
+	return nil
+}
+
+// DimensionName resolves a dimension alias declared in the file's meta to its name.
+// Unknown aliases are returned as is.
+func (f EvaluationFile) DimensionName(alias string) string {
+	if dimension := f.Dimension(alias); dimension != nil {
+		return dimension.Name
+	}
+	return alias
+}
+
+// IsMeta returns true if the file holds meta evaluations of the mentor's teaching.
+func (f EvaluationFile) IsMeta() bool {
+	return f.Definition.Tomegg.Subtype == pkg.EvaluationSubtypeMeta
+}
+
+// MentorName returns the name of the mentor rated by meta evaluations, "mentor" when undeclared.
+func (f EvaluationFile) MentorName() string {
+	if mentor := f.Definition.Meta.Mentor; mentor != nil && mentor.Name != "" {
+		return mentor.Name
+	}
+	return "mentor"
+}
+
+// IsSelf returns true if the file holds self evaluations.
+func (f EvaluationFile) IsSelf() bool {
+	return IsSelfEvaluation(f.Filepath)
+}
+
+// EvaluatorName returns who wrote the evaluations of the file: its declared
+// evaluator, "self" for undeclared self evaluations, else the file name.
+func (f EvaluationFile) EvaluatorName() string {
+	if evaluator := f.Definition.Meta.Evaluator; evaluator != nil && evaluator.Name != "" {
+		return evaluator.Name
+	}
+	if f.IsSelf() {
+		return SelfEvaluator
+	}
+	return filepath.Base(f.Filepath)
+}
+
+// EvaluatorID identifies who wrote the evaluations of the file, so that files
+// by the same evaluator can be told apart from files by others: the declared
+// eth, else email, else evaluator name, lowercased.
+func (f EvaluationFile) EvaluatorID() string {
+	if evaluator := f.Definition.Meta.Evaluator; evaluator != nil {
+		for _, handle := range []string{evaluator.Socials.Eth, evaluator.Socials.Email} {
+			if handle != "" {
+				return strings.ToLower(handle)
+			}
+		}
+	}
+	return strings.ToLower(f.EvaluatorName())
+}
+
+// EvaluatedBy returns true if the evaluations of the file were written by
+// who, matched case-insensitively against the evaluator name, email and eth.
+func (f EvaluationFile) EvaluatedBy(who string) bool {
+	if strings.EqualFold(who, f.EvaluatorName()) {
+		return true
+	}
+	evaluator := f.Definition.Meta.Evaluator
+	if evaluator == nil {
+		return false
+	}
+	for _, handle := range []string{evaluator.Socials.Email, evaluator.Socials.Eth} {
+		if handle != "" && strings.EqualFold(who, handle) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetEvaluationFiles collects all evaluation files of the plan.
+func GetEvaluationFiles(plan *pkg.ValidationPlan) ([]EvaluationFile, error) {
+	var evaluationFiles []EvaluationFile
+
+	for _, file := range plan.Files {
+		if !strings.Contains(file.Filepath, "evaluations") {
+			continue
+		}
+
+		fileBytes, err := os.ReadFile(file.Filepath)
+		if err != nil {
+			continue // Skip files we can't read
+		}
+
+		result := pkg.EvaluationDefinition[pkg.Measurement]{}
+		err = yaml.Unmarshal(fileBytes, &result)
+		if err != nil {
+			logrus.WithField("file", file.Filepath).Warnf("skipping evaluation file: %s", err)
+			continue // Skip invalid YAML files
+		}
+
+		if result.Tomegg.Type != "evaluations" {
+			continue // Skip non-evaluation files
+		}
+
+		evaluationFiles = append(evaluationFiles, EvaluationFile{
+			Filepath:   file.Filepath,
+			Definition: result,
+		})
+	}
+
+	return evaluationFiles, nil
+}
+
+// SignedAsWhole returns true if the file carries a signature of its whole
+// content, which any change to the file invalidates.
+func (f EvaluationFile) SignedAsWhole() bool {
+	for _, signature := range f.Definition.Meta.Signatures {
+		if len(signature.Records) == 0 {
+			return true
+		}
+	}
+	return false
+}
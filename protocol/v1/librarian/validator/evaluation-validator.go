@@ -13,9 +13,21 @@ import (
 type evaluationValidator struct {
 	log *logrus.Entry
 	plan *pkg.ValidationPlan
+
+	// catalog holds the known dimensions, unless it failed to load with catalogErr.
+	catalog    *pkg.DimensionCatalog
+	catalogErr error
+
+	// aliases maps each dimension alias declared in the repository to its
+	// first declaration, so that it resolves to the same dimension everywhere.
+	aliases map[string]declaredAlias
 }
 
-var registeredDimensions []string
+// declaredAlias is the dimension an alias was first declared for, and where.
+type declaredAlias struct {
+	pkg.DimensionDeclaration
+	file string
+}
 
 // File implements Validator
 func (m *evaluationValidator) File(dir *pkg.File) error {
@@ -23,12 +35,16 @@ func (m *evaluationValidator) File(dir *pkg.File) error {
 		return nil
 	}
 
+	if m.catalogErr != nil {
+		return m.catalogErr
+	}
+
 	fileBytes, err := os.ReadFile(dir.Filepath)
 	if err != nil {
 		return err
 	}
 
-	result := pkg.EvaluationDefinition[pkg.StandardMeasurement]{}
+	result := pkg.EvaluationDefinition[pkg.Measurement]{}
 	err = yaml.Unmarshal(fileBytes, &result)
 
 	if err != nil {
@@ -43,6 +59,11 @@ func (m *evaluationValidator) File(dir *pkg.File) error {
 		return ErrUnsupportedVersion
 	}
 
+	validateSubtype, ok := evaluationSubtypes[result.Tomegg.Subtype]
+	if !ok {
+		return ErrUnsupportedSubtype(result.Tomegg.Subtype)
+	}
+
 	expectedTomeggDef := fmt.Sprintf("https://protocol.tome.gg/%s/%s", result.Tomegg.Type, result.Tomegg.Version)
 	if result.Tomegg.Definition != expectedTomeggDef {
 		err := ErrMismatchedTomeggDefinition(expectedTomeggDef, result.Tomegg.Definition)
@@ -50,37 +71,115 @@ func (m *evaluationValidator) File(dir *pkg.File) error {
 		return err
 	}
 
+	if result.Meta.Evaluator != nil {
+		if err := ValidateEvaluator(*result.Meta.Evaluator); err != nil {
+			return err
+		}
+	}
+
 	if len(result.Meta.Dimensions) == 0 {
 		return ErrNoDimension
 	}
 
+	declared := map[string]bool{}
 	for _, dimension := range result.Meta.Dimensions {
-		expectedDimensionDef := fmt.Sprintf("https://protocol.tome.gg/dimensions/%s/%s", dimension.Name, dimension.Version)
+		if declared[dimension.Alias] {
+			return ErrDuplicateDimensionAlias(dimension.Alias, dir.Filepath)
+		}
+		declared[dimension.Alias] = true
+
+		// Official dimensions and versions missing from the catalogue are only
+		// reported, since the embedded official catalogue does not list all of
+		// them yet; they must then use the official definition URL. The
+		// repository's own dimensions must be in its catalogue.
+		expectedDimensionDef := fmt.Sprintf("%s/%s/%s", pkg.OfficialDimensionsURL, dimension.Name, dimension.Version)
+		catalogued, ok := m.catalog.Lookup(dimension.Name)
+		if !ok {
+			m.log.WithField("file", dir.Filepath).Warn(ErrUnknownDimension(dimension.Name))
+		} else {
+			if _, ok := catalogued.Version(dimension.Version); !ok {
+				if !catalogued.Official {
+					return ErrUnknownDimensionVersion(dimension.Name, dimension.Version)
+				}
+				m.log.WithField("file", dir.Filepath).Warn(ErrUnknownDimensionVersion(dimension.Name, dimension.Version))
+			}
+			expectedDimensionDef = catalogued.DefinitionURL(dimension.Version)
+		}
+
 		if dimension.Definition != expectedDimensionDef {
 			err := ErrMismatchedDimensionDefinition(dimension.Name, expectedDimensionDef, dimension.Definition)
 			logrus.Error(err)
 			return err
 		}
-		registeredDimensions = append(registeredDimensions, dimension.Name, dimension.Alias)
+		if err := ValidateDimensionKind(dimension, m.catalog); err != nil {
+			return err
+		}
+		if err := m.registerAlias(dir.Filepath, dimension); err != nil {
+			return err
+		}
+	}
+
+	if err := validateSubtype(dir.Filepath, result); err != nil {
+		return err
 	}
 
 	if len(result.Evaluations) == 0 {
 		logrus.WithField("file", dir.Filepath).Warnf("empty evaluations set")
 	}
 
+	file := EvaluationFile{Filepath: dir.Filepath, Definition: result}
+
+	// Signatures by an evaluator pinned in tome.yaml must be made with its
+	// key; the others can only be checked against the content they sign.
+	trusted, pinned := TrustedKeys(m.plan.Config, file, nil)
+	var mismatch error
+	for _, signature := range result.Meta.Signatures {
+		var err error
+		if pinned {
+			err = VerifySignature(fileBytes, signature, trusted)
+		} else {
+			err = CheckSignature(fileBytes, signature)
+		}
+		if err != nil {
+			m.log.WithField("file", dir.Filepath).Error(err)
+			if mismatch == nil {
+				mismatch = err
+			}
+			continue
+		}
+		if !pinned {
+			m.log.WithField("file", dir.Filepath).Warnf("signature of %s by key %s cannot be trusted: %s has no key pinned in tome.yaml", SignedContent(signature), KeyFingerprint(signature.PublicKey), file.EvaluatorName())
+		}
+	}
+	if mismatch != nil {
+		return mismatch
+	}
+	if pinned {
+		if err := RequireSignedEvaluations(fileBytes, file, trusted); err != nil {
+			return err
+		}
+	}
+
 	for _, records := range result.Evaluations {
-		err := m.validateEvaluationRecord(records)
+		err := m.validateEvaluationRecord(file, records)
 		if err != nil {
 			return err
 		}
 	}
 
-	m.log.Infof("ok")
+	for _, dimension := range UnusedDimensions(file) {
+		m.log.WithFields(logrus.Fields{
+			"file":      dir.Filepath,
+			"dimension": dimension.Alias,
+		}).Warn("dimension declared but never scored")
+	}
+
+	m.log.WithField("subtype", result.Tomegg.Subtype).Infof("ok")
 
 	return nil
 }
 
-func (m *evaluationValidator) validateEvaluationRecord(records pkg.EvaluationRecord[pkg.StandardMeasurement]) error {
+func (m *evaluationValidator) validateEvaluationRecord(file EvaluationFile, records pkg.EvaluationRecord[pkg.Measurement]) error {
 	if records.ID == "" {
 		return ErrRequiredField(records.ID, "id")
 	}
@@ -100,32 +199,88 @@ func (m *evaluationValidator) validateEvaluationRecord(records pkg.EvaluationRec
 		return ErrNoMeasurements
 	}
 
+	return ValidateMeasurements(file, records, m.catalog)
+}
+
+// ValidateMeasurements checks the measurements of an evaluation record against
+// the dimensions declared by its evaluation file, decoding each one by the kind
+// of its dimension. Rubric levels are checked against the catalogue, if any.
+func ValidateMeasurements(file EvaluationFile, records pkg.EvaluationRecord[pkg.Measurement], catalog *pkg.DimensionCatalog) error {
+	if len(records.Measurements) == 0 {
+		return ErrNoMeasurements
+	}
+
 	for _, measure := range records.Measurements {
 
 		if strings.TrimSpace(measure.Dimension) == "" {
 			return ErrRequiredField(records.ID, "dimension")
 		}
 
-		if measure.Score == nil {
-			return ErrRequiredField(records.ID, "score")
+		dimension := file.Dimension(measure.Dimension)
+		if dimension == nil {
+			return ErrUndeclaredDimension(records.ID, measure.Dimension, file.Filepath)
 		}
 
-		isRegistered := false
-		for _, allowed := range registeredDimensions {
-			if allowed == measure.Dimension {
-				isRegistered = true
-				break
-			}
+		kind, ok := measurementKinds[dimension.MeasurementKind()]
+		if !ok {
+			return ErrUnsupportedMeasurementKind(dimension.Name, dimension.Kind)
+		}
+
+		value, err := DecodeMeasurement(*dimension, measure)
+		if err != nil {
+			return ErrInvalidMeasurement(records.ID, dimension.Name, err.Error())
 		}
 
-		if isRegistered == false {
-			m.log.WithField("dimension", measure.Dimension).Warn(ErrUnregisteredDimension)
+		if kind.Validate == nil {
+			continue
+		}
+		if err := kind.Validate(*dimension, value, catalog); err != nil {
+			return ErrInvalidMeasurement(records.ID, dimension.Name, err.Error())
 		}
 	}
 
 	return nil
 }
 
+// registerAlias records the dimension declared under an alias, and checks that
+// the alias resolves to the same dimension name and version in every file.
+func (m *evaluationValidator) registerAlias(path string, dimension pkg.DimensionDeclaration) error {
+	first, ok := m.aliases[dimension.Alias]
+	if !ok {
+		m.aliases[dimension.Alias] = declaredAlias{DimensionDeclaration: dimension, file: path}
+		return nil
+	}
+
+	if first.Name != dimension.Name || first.Version != dimension.Version {
+		return ErrInconsistentDimensionAlias(dimension.Alias,
+			fmt.Sprintf("%s %s in %s", first.Name, first.Version, first.file),
+			fmt.Sprintf("%s %s in %s", dimension.Name, dimension.Version, path))
+	}
+
+	return nil
+}
+
+// UnusedDimensions returns the dimensions declared by an evaluation file that
+// none of its measurements score.
+func UnusedDimensions(file EvaluationFile) []pkg.DimensionDeclaration {
+	used := map[string]bool{}
+	for _, record := range file.Definition.Evaluations {
+		for _, measurement := range record.Measurements {
+			if dimension := file.Dimension(measurement.Dimension); dimension != nil {
+				used[dimension.Alias] = true
+			}
+		}
+	}
+
+	unused := []pkg.DimensionDeclaration{}
+	for _, dimension := range file.Definition.Meta.Dimensions {
+		if !used[dimension.Alias] {
+			unused = append(unused, dimension)
+		}
+	}
+	return unused
+}
+
 // Validate defines the process for validating a certain directory.
 func (m *evaluationValidator) Directory(dir *pkg.Directory) error {
 	if strings.Contains(dir.Path, "evaluations") == false {
@@ -137,10 +292,15 @@ func (m *evaluationValidator) Directory(dir *pkg.Directory) error {
 
 // NewEvaluationValidator ...
 func NewEvaluationValidator(plan *pkg.ValidationPlan) Validator {
+	catalog, err := pkg.LoadDimensionCatalog(plan.Config)
+
 	return &evaluationValidator{
 		log: logrus.WithFields(logrus.Fields{
 			"validator": "evaluation",
 		}),
-		plan: plan,
+		plan:       plan,
+		catalog:    catalog,
+		catalogErr: err,
+		aliases:    map[string]declaredAlias{},
 	}
 }
@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	"gopkg.in/yaml.v2"
+)
+
+// ReadEvaluationFile reads and parses a single evaluation file.
+func ReadEvaluationFile(path string) (EvaluationFile, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return EvaluationFile{}, err
+	}
+
+	result := pkg.EvaluationDefinition[pkg.Measurement]{}
+	if err := yaml.Unmarshal(fileBytes, &result); err != nil {
+		return EvaluationFile{}, fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+
+	if result.Tomegg.Type != "evaluations" {
+		return EvaluationFile{}, fmt.Errorf("%s is not an evaluation file", path)
+	}
+
+	return EvaluationFile{Filepath: path, Definition: result}, nil
+}
+
+// RenderEvaluationRecord renders an evaluation record as a YAML mapping,
+// using literal blocks for remarks, wins and mistakes. The fields of other
+// measurement kinds than standard follow the score.
+func RenderEvaluationRecord(record pkg.EvaluationRecord[pkg.Measurement]) string {
+	return strings.Join(renderEvaluationFields(record), "\n") + "\n"
+}
+
+func renderEvaluationFields(record pkg.EvaluationRecord[pkg.Measurement]) []string {
+	lines := []string{
+		fmt.Sprintf("id: %s", record.ID),
+		"measurements:",
+	}
+	for _, measurement := range record.Measurements {
+		fields := []string{fmt.Sprintf("dimension: %s", measurement.Dimension)}
+		if measurement.Score != nil {
+			fields = append(fields, fmt.Sprintf("score: %d", *measurement.Score))
+		}
+		values, err := yaml.Marshal(struct {
+			pkg.RubricSelection `yaml:",inline"`
+			pkg.Checklist       `yaml:",inline"`
+			pkg.PassFail        `yaml:",inline"`
+			Fields              map[string]interface{} `yaml:",inline"`
+		}{measurement.RubricSelection, measurement.Checklist, measurement.PassFail, measurement.Fields})
+		if err == nil && strings.TrimSpace(string(values)) != "{}" {
+			fields = append(fields, strings.Split(strings.TrimRight(string(values), "\n"), "\n")...)
+		}
+		for _, field := range []struct{ key, value string }{
+			{"remarks", measurement.Remarks},
+			{"wins", measurement.Wins},
+			{"mistakes", measurement.Mistakes},
+		} {
+			if field.value != "" {
+				fields = append(fields, renderBlockScalar(field.key, field.value)...)
+			}
+		}
+		lines = append(lines, renderListItem(fields, "  ")...)
+	}
+	return lines
+}
+
+// AppendEvaluationRecord appends the record to the evaluations of an evaluation
+// file, leaving the rest of the file untouched. It invalidates a signature of the
+// whole file, see EvaluationFile.SignedAsWhole.
+func AppendEvaluationRecord(path string, record pkg.EvaluationRecord[pkg.Measurement]) error {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	doc := parseSequenceDocument(fileBytes, "evaluations")
+	doc.appendItem(renderListItem(renderEvaluationFields(record), doc.itemIndent()))
+
+	return writeFilePreservingMode(path, doc.bytes())
+}
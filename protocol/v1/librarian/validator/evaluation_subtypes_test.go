@@ -0,0 +1,47 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+func TestEvaluationSubtypes(t *testing.T) {
+	if !IsEvaluationSubtype(pkg.EvaluationSubtypeStandard) || !IsEvaluationSubtype(pkg.EvaluationSubtypeMeta) || IsEvaluationSubtype("peer") {
+		t.Error("Expected the standard and meta subtypes to be the only supported subtypes")
+	}
+
+	score := 3
+	teaching := []pkg.DimensionDeclaration{{Alias: "t", Name: pkg.TeachingDimension, Version: "0.1.0"}}
+
+	standard := pkg.EvaluationDefinition[pkg.Measurement]{}
+	standard.Meta.Dimensions = teaching
+	if err := validateStandardEvaluation("evaluations/self.yaml", standard); err == nil {
+		t.Error("Expected standard evaluations to reject the teaching dimension")
+	}
+	standard.Meta.Dimensions = []pkg.DimensionDeclaration{{Alias: "focus", Name: "focus", Version: "0.1.0"}}
+	if err := validateStandardEvaluation("evaluations/mentor.yaml", standard); err == nil {
+		t.Error("Expected standard evaluations by a mentor to require an evaluator")
+	}
+
+	meta := pkg.EvaluationDefinition[pkg.Measurement]{}
+	meta.Meta.Dimensions = teaching
+	meta.Evaluations = []pkg.EvaluationRecord[pkg.Measurement]{
+		{ID: "a", Measurements: []pkg.Measurement{{Dimension: "t", Score: &score}}},
+	}
+	if err := validateMetaEvaluation("evaluations/meta/self.yaml", meta); err != nil {
+		t.Errorf("Expected a valid meta evaluation, but got %s", err)
+	}
+
+	meta.Evaluations = append(meta.Evaluations, meta.Evaluations[0])
+	if err := validateMetaEvaluation("evaluations/meta/self.yaml", meta); err == nil {
+		t.Error("Expected meta evaluations to rate a training entry once")
+	}
+
+	meta.Evaluations = []pkg.EvaluationRecord[pkg.Measurement]{
+		{ID: "b", Measurements: []pkg.Measurement{{Dimension: "focus", Score: &score}}},
+	}
+	if err := validateMetaEvaluation("evaluations/meta/self.yaml", meta); err == nil {
+		t.Error("Expected meta evaluations to rate the teaching")
+	}
+}
@@ -0,0 +1,66 @@
+package validator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+func TestEvaluationValidatorDimensionConsistency(t *testing.T) {
+	root := t.TempDir()
+	writeMockFile(t, filepath.Join(root, "dimensions", "grit.yaml"), mockCatalogDimension)
+
+	config, err := pkg.LoadConfig(root)
+	if err != nil {
+		t.Fatalf("failed to load config: %s", err)
+	}
+	plan := pkg.NewValidationPlan(nil, nil)
+	plan.Config = config
+	plan.Metadata["registeredTraining"] = []string{"e1"}
+	plan.Metadata["validTraining"] = []string{"e1"}
+	validator := NewEvaluationValidator(plan)
+
+	validate := func(name string, content string) error {
+		path := filepath.Join(root, "evaluations", name)
+		writeMockFile(t, path, content)
+		return validator.File(&pkg.File{Filepath: path})
+	}
+
+	if err := validate("self.yaml", mockCatalogEvaluationFile); err != nil {
+		t.Fatalf("Expected the first file to be valid, but got %s", err)
+	}
+
+	older := strings.ReplaceAll(mockCatalogEvaluationFile, "0.2.0", "0.1.0")
+	if err := validate("meta/self.yaml", older); err == nil || !strings.Contains(err.Error(), "alias g") {
+		t.Errorf("Expected alias g to resolve to different versions, but got %v", err)
+	}
+
+	undeclared := strings.Replace(mockCatalogEvaluationFile, "dimension: g", "dimension: stamina", 1)
+	if err := validate("self.yaml", undeclared); err == nil || !strings.Contains(err.Error(), "stamina") {
+		t.Errorf("Expected an error for a measurement of an undeclared dimension, but got %v", err)
+	}
+
+	duplicate := strings.Replace(mockCatalogEvaluationFile, "alias: focus", "alias: g", 1)
+	if err := validate("self.yaml", duplicate); err == nil {
+		t.Error("Expected an error for an alias declared twice in a file")
+	}
+}
+
+func TestUnusedDimensions(t *testing.T) {
+	score := 3
+	file := EvaluationFile{Filepath: "evaluations/self.yaml"}
+	file.Definition.Meta.Dimensions = []pkg.DimensionDeclaration{
+		{Alias: "f", Name: "focus"},
+		{Alias: "grit", Name: "grit"},
+	}
+	file.Definition.Evaluations = []pkg.EvaluationRecord[pkg.Measurement]{
+		{ID: "e1", Measurements: []pkg.Measurement{{Dimension: "focus", Score: &score}}},
+	}
+
+	unused := UnusedDimensions(file)
+	if len(unused) != 1 || unused[0].Alias != "grit" {
+		t.Errorf("Expected grit to be unused, but got %+v", unused)
+	}
+}
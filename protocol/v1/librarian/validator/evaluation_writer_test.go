@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+const mockWriterEvaluationFile = `# Evaluations by the apprentice
+tomegg:
+  type: evaluations
+  version: 0.1.0
+  definition: https://protocol.tome.gg/evaluations/0.1.0
+
+meta:
+  dimensions:
+    - alias: focus
+      name: focus
+      version: 0.1.0
+      definition: https://protocol.tome.gg/dimensions/focus/0.1.0
+      scale:
+        min: 1
+        max: 5
+
+evaluations:
+  # First week
+  - id: 385d9c24-be5c-5032-a163-7ddab2d35a78
+    measurements:
+      - dimension: focus
+        score: 2
+`
+
+func TestAppendEvaluationRecord(t *testing.T) {
+	path := writeMockFile(t, filepath.Join(t.TempDir(), "self.yaml"), mockWriterEvaluationFile)
+
+	score := 4
+	record := pkg.EvaluationRecord[pkg.Measurement]{
+		ID: "a7fd6a39-b857-585f-9233-85cec2027477",
+		Measurements: []pkg.Measurement{
+			{Dimension: "focus", Score: &score, Remarks: "Kept at it.\nNo context switches.", Wins: "Shipped"},
+		},
+	}
+
+	file, err := ReadEvaluationFile(path)
+	if err != nil {
+		t.Fatalf("ReadEvaluationFile failed: %s", err)
+	}
+	if err := ValidateMeasurements(file, record, nil); err != nil {
+		t.Fatalf("Expected the record to be valid, but got %s", err)
+	}
+
+	if err := AppendEvaluationRecord(path, record); err != nil {
+		t.Fatalf("AppendEvaluationRecord failed: %s", err)
+	}
+
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read evaluation file: %s", err)
+	}
+	for _, comment := range []string{"# Evaluations by the apprentice", "# First week"} {
+		if !strings.Contains(string(fileBytes), comment) {
+			t.Errorf("Expected comment %q to be kept", comment)
+		}
+	}
+
+	file, err = ReadEvaluationFile(path)
+	if err != nil {
+		t.Fatalf("written evaluation file is not valid: %s", err)
+	}
+	evaluations := file.Definition.Evaluations
+	if len(evaluations) != 2 {
+		t.Fatalf("Expected 2 evaluations, but found %d", len(evaluations))
+	}
+
+	appended := evaluations[1]
+	if appended.ID != record.ID || len(appended.Measurements) != 1 {
+		t.Fatalf("Expected the appended record, but got %+v", appended)
+	}
+	measurement := appended.Measurements[0]
+	if measurement.Score == nil || *measurement.Score != score {
+		t.Errorf("Expected score %d, but got %v", score, measurement.Score)
+	}
+	if measurement.Remarks != record.Measurements[0].Remarks || measurement.Wins != "Shipped" {
+		t.Errorf("Expected remarks and wins to round-trip, but got %q and %q", measurement.Remarks, measurement.Wins)
+	}
+}
+
+func TestValidateMeasurementsOutOfScale(t *testing.T) {
+	path := writeMockFile(t, filepath.Join(t.TempDir(), "self.yaml"), mockWriterEvaluationFile)
+
+	file, err := ReadEvaluationFile(path)
+	if err != nil {
+		t.Fatalf("ReadEvaluationFile failed: %s", err)
+	}
+
+	score := 9
+	record := pkg.EvaluationRecord[pkg.Measurement]{
+		ID:           "a7fd6a39-b857-585f-9233-85cec2027477",
+		Measurements: []pkg.Measurement{{Dimension: "focus", Score: &score}},
+	}
+	if err := ValidateMeasurements(file, record, nil); err == nil {
+		t.Error("Expected an error for a score outside of the scale")
+	}
+
+	record.Measurements[0].Dimension = "grit"
+	if err := ValidateMeasurements(file, record, nil); err == nil {
+		t.Error("Expected an error for an undeclared dimension")
+	}
+}
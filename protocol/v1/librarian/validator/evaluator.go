@@ -0,0 +1,46 @@
+package validator
+
+import (
+	"net/mail"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+// SelfEvaluator is the evaluator name of self evaluations that do not declare an evaluator.
+const SelfEvaluator = "self"
+
+var (
+	ensNamePattern    = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+eth$`)
+	ethAddressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+)
+
+// IsSelfEvaluation returns true for evaluation files written by the apprentice
+// about their own training, which are named self.yaml.
+func IsSelfEvaluation(path string) bool {
+	name := filepath.Base(path)
+	return strings.TrimSuffix(name, filepath.Ext(name)) == SelfEvaluator
+}
+
+// ValidateEvaluator checks the identity of an evaluator. Only the format of
+// the socials is checked, no lookup is made.
+func ValidateEvaluator(e pkg.Evaluator) error {
+	if strings.TrimSpace(e.Name) == "" {
+		return ErrInvalidEvaluator("name", "missing name")
+	}
+
+	if e.Socials.Email != "" {
+		address, err := mail.ParseAddress(e.Socials.Email)
+		if err != nil || address.Address != e.Socials.Email {
+			return ErrInvalidEvaluator("socials.email", "not an email address: "+e.Socials.Email)
+		}
+	}
+
+	if e.Socials.Eth != "" && !ensNamePattern.MatchString(e.Socials.Eth) && !ethAddressPattern.MatchString(e.Socials.Eth) {
+		return ErrInvalidEvaluator("socials.eth", "not an ENS name or 0x address: "+e.Socials.Eth)
+	}
+
+	return nil
+}
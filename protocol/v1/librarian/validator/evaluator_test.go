@@ -0,0 +1,49 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+func TestValidateEvaluator(t *testing.T) {
+	valid := []pkg.Evaluator{
+		{Name: "Darren"},
+		{Name: "Darren", Socials: pkg.EvaluatorSocials{Email: "darren@tome.gg", Eth: "sapalo.eth"}},
+		{Name: "Mentor", Socials: pkg.EvaluatorSocials{Eth: "0x52908400098527886E0F7030069857D2E4169EE7"}},
+	}
+	for _, evaluator := range valid {
+		if err := ValidateEvaluator(evaluator); err != nil {
+			t.Errorf("Expected %+v to be valid, but got %s", evaluator, err)
+		}
+	}
+
+	invalid := []pkg.Evaluator{
+		{Socials: pkg.EvaluatorSocials{Email: "darren@tome.gg"}},
+		{Name: "Darren", Socials: pkg.EvaluatorSocials{Email: "darren"}},
+		{Name: "Darren", Socials: pkg.EvaluatorSocials{Email: "Darren <darren@tome.gg>"}},
+		{Name: "Darren", Socials: pkg.EvaluatorSocials{Eth: "sapalo"}},
+		{Name: "Darren", Socials: pkg.EvaluatorSocials{Eth: "0x1234"}},
+	}
+	for _, evaluator := range invalid {
+		if err := ValidateEvaluator(evaluator); err == nil {
+			t.Errorf("Expected %+v to be invalid", evaluator)
+		}
+	}
+}
+
+func TestEvaluationFileEvaluator(t *testing.T) {
+	self := EvaluationFile{Filepath: "evaluations/self.yaml"}
+	if !self.IsSelf() || self.EvaluatorName() != SelfEvaluator || !self.EvaluatedBy("SELF") {
+		t.Errorf("Expected an undeclared self evaluation to be evaluated by self, but got %s", self.EvaluatorName())
+	}
+
+	mentor := EvaluationFile{Filepath: "evaluations/mentor.yaml"}
+	mentor.Definition.Meta.Evaluator = &pkg.Evaluator{Name: "Ada", Socials: pkg.EvaluatorSocials{Email: "ada@example.com"}}
+	if mentor.IsSelf() || mentor.EvaluatorName() != "Ada" {
+		t.Errorf("Expected the mentor file to be evaluated by Ada, but got %s", mentor.EvaluatorName())
+	}
+	if !mentor.EvaluatedBy("ada") || !mentor.EvaluatedBy("ADA@example.com") || mentor.EvaluatedBy("self") {
+		t.Error("Expected the mentor file to match Ada's name and email only")
+	}
+}
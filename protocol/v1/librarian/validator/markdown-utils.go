@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "in": true, "is": true,
+	"it": true, "of": true, "on": true, "or": true, "the": true, "to": true,
+	"with": true, "my": true, "i": true, "still": true,
+}
+
+var checkboxPattern = regexp.MustCompile(`^\[[ xX]\]\s*`)
+
+// SplitListItems splits Markdown text into its top-level list items. Nested
+// items and continuation lines are kept with their parent item. Text without
+// any list marker is returned as a single item.
+func SplitListItems(text string) []string {
+	items := []string{}
+	current := []string{}
+	indent := -1
+
+	flush := func() {
+		item := strings.TrimSpace(strings.Join(current, " "))
+		if item != "" {
+			items = append(items, item)
+		}
+		current = []string{}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		lineIndent := len(line) - len(strings.TrimLeft(line, " \t"))
+		marker := listMarkerPattern.FindString(line)
+		if marker != "" && (indent == -1 || lineIndent <= indent) {
+			flush()
+			indent = lineIndent
+			current = append(current, checkboxPattern.ReplaceAllString(strings.TrimSpace(line[len(marker):]), ""))
+			continue
+		}
+
+		current = append(current, strings.TrimSpace(listMarkerPattern.ReplaceAllString(line, "")))
+	}
+	flush()
+
+	return items
+}
+
+// tokenize lowercases the text and splits it into words, dropping punctuation
+// and stop words.
+func tokenize(text string) map[string]bool {
+	tokens := map[string]bool{}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if !stopWords[word] {
+			tokens[word] = true
+		}
+	}
+	return tokens
+}
+
+// TextSimilarity returns the Dice coefficient of the word sets of a and b,
+// from 0 (nothing in common) to 1 (same words).
+func TextSimilarity(a string, b string) float64 {
+	tokensA, tokensB := tokenize(a), tokenize(b)
+	if len(tokensA) == 0 && len(tokensB) == 0 {
+		return 1
+	}
+	if len(tokensA) == 0 || len(tokensB) == 0 {
+		return 0
+	}
+
+	common := 0
+	for token := range tokensA {
+		if tokensB[token] {
+			common++
+		}
+	}
+
+	return 2 * float64(common) / float64(len(tokensA)+len(tokensB))
+}
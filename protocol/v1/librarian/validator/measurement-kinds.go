@@ -0,0 +1,299 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	"gopkg.in/yaml.v2"
+)
+
+// MeasurementKind decodes and validates the measurements of the dimensions
+// declaring it in their kind.
+type MeasurementKind struct {
+	// Field is the field holding the value of a measurement of this kind.
+	// Measurements of other kinds may not set it.
+	Field string
+	// Declare checks the declaration of a dimension of this kind, if set.
+	Declare func(dimension pkg.DimensionDeclaration, catalog *pkg.DimensionCatalog) error
+	// Decode reads the value of a measurement. Kinds registered outside of
+	// this package find their field in the Fields of the measurement, see
+	// DecodeMeasurementField.
+	Decode func(measurement pkg.Measurement) (pkg.MeasurementValue, error)
+	// Validate checks a decoded value against the declaration of its dimension, if set.
+	Validate func(dimension pkg.DimensionDeclaration, value pkg.MeasurementValue, catalog *pkg.DimensionCatalog) error
+}
+
+// measurementKinds maps the kind declared by a dimension to its decoder and validators.
+var measurementKinds = map[string]MeasurementKind{
+	pkg.MeasurementStandard: {
+		Field:    "score",
+		Declare:  declareScaledDimension,
+		Decode:   decodeStandardScore,
+		Validate: validateStandardScore,
+	},
+	pkg.MeasurementRubric: {
+		Field:    "level",
+		Declare:  declareRubricDimension,
+		Decode:   decodeRubricSelection,
+		Validate: validateRubricSelection,
+	},
+	pkg.MeasurementChecklist: {
+		Field:    "checklist",
+		Declare:  declareChecklistDimension,
+		Decode:   decodeChecklist,
+		Validate: validateChecklist,
+	},
+	pkg.MeasurementPassFail: {
+		Field:    "passed",
+		Declare:  declareUnscaledDimension,
+		Decode:   decodePassFail,
+		Validate: validatePassFail,
+	},
+}
+
+// RegisterMeasurementKind adds a kind of measurement that dimensions can
+// declare. It is meant to be called during initialization, before validating
+// or reading evaluation files.
+func RegisterMeasurementKind(name string, kind MeasurementKind) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("missing measurement kind name")
+	}
+	if _, ok := measurementKinds[name]; ok {
+		return fmt.Errorf("measurement kind %s is already registered", name)
+	}
+	if kind.Field == "" || kind.Decode == nil {
+		return fmt.Errorf("measurement kind %s needs a field and a decoder", name)
+	}
+	for _, registered := range measurementKinds {
+		if registered.Field == kind.Field {
+			return fmt.Errorf("field %s of measurement kind %s is already used by another kind", kind.Field, name)
+		}
+	}
+	measurementKinds[name] = kind
+	return nil
+}
+
+// DecodeMeasurementField decodes the field of a measurement kind registered
+// outside of this package into value, e.g. a pointer to a struct.
+func DecodeMeasurementField(measurement pkg.Measurement, field string, value interface{}) error {
+	raw, ok := measurement.Fields[field]
+	if !ok {
+		return errMissingMeasurementField(field)
+	}
+	fieldBytes, err := yaml.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	if err := yaml.UnmarshalStrict(fieldBytes, value); err != nil {
+		return fmt.Errorf("invalid %s: %s", field, err)
+	}
+	return nil
+}
+
+// IsMeasurementKind returns true if the kind is a supported measurement kind.
+func IsMeasurementKind(kind string) bool {
+	_, ok := measurementKinds[kind]
+	return ok
+}
+
+// ValidateDimensionKind checks that the dimension declares a supported kind of
+// measurement, along with what that kind needs.
+func ValidateDimensionKind(dimension pkg.DimensionDeclaration, catalog *pkg.DimensionCatalog) error {
+	kind, ok := measurementKinds[dimension.MeasurementKind()]
+	if !ok {
+		return ErrUnsupportedMeasurementKind(dimension.Name, dimension.Kind)
+	}
+	if dimension.MeasurementKind() != pkg.MeasurementChecklist && len(dimension.Criteria) > 0 {
+		return ErrInvalidDimensionKind(dimension.Name, dimension.MeasurementKind(), "criteria are only used by checklists")
+	}
+	if kind.Declare == nil {
+		return nil
+	}
+	return kind.Declare(dimension, catalog)
+}
+
+// DecodeMeasurement decodes the value of a measurement by the kind of its
+// dimension, rejecting the values of other kinds.
+func DecodeMeasurement(dimension pkg.DimensionDeclaration, measurement pkg.Measurement) (pkg.MeasurementValue, error) {
+	kind, ok := measurementKinds[dimension.MeasurementKind()]
+	if !ok {
+		return nil, ErrUnsupportedMeasurementKind(dimension.Name, dimension.Kind)
+	}
+	for _, field := range measurementFields(measurement) {
+		if field != kind.Field {
+			return nil, fmt.Errorf("unexpected field %s in a %s measurement", field, dimension.MeasurementKind())
+		}
+	}
+	return kind.Decode(measurement)
+}
+
+// measurementFields returns the fields of the measurement holding the value
+// of a measurement kind.
+func measurementFields(measurement pkg.Measurement) []string {
+	fields := []string{}
+	if measurement.Score != nil {
+		fields = append(fields, "score")
+	}
+	if measurement.Level != nil {
+		fields = append(fields, "level")
+	}
+	if measurement.Checklist.Checklist != nil {
+		fields = append(fields, "checklist")
+	}
+	if measurement.Passed != nil {
+		fields = append(fields, "passed")
+	}
+	others := make([]string, 0, len(measurement.Fields))
+	for field := range measurement.Fields {
+		others = append(others, field)
+	}
+	sort.Strings(others)
+	return append(fields, others...)
+}
+
+// RubricLevels returns the levels a rubric selection of the dimension can
+// pick: the labels of its scale, else the rubric of its declared version in
+// the catalogue.
+func RubricLevels(dimension pkg.DimensionDeclaration, catalog *pkg.DimensionCatalog) map[int]string {
+	if dimension.Scale != nil && len(dimension.Scale.Labels) > 0 {
+		return dimension.Scale.Labels
+	}
+	if catalog == nil {
+		return nil
+	}
+	catalogued, ok := catalog.Lookup(dimension.Name)
+	if !ok {
+		return nil
+	}
+	version, ok := catalogued.Version(dimension.Version)
+	if !ok {
+		return nil
+	}
+	return version.Rubric
+}
+
+func declareScaledDimension(dimension pkg.DimensionDeclaration, catalog *pkg.DimensionCatalog) error {
+	if dimension.Scale == nil {
+		return nil
+	}
+	return ValidateScale(dimension.Name, *dimension.Scale)
+}
+
+func declareRubricDimension(dimension pkg.DimensionDeclaration, catalog *pkg.DimensionCatalog) error {
+	if err := declareScaledDimension(dimension, catalog); err != nil {
+		return err
+	}
+	if catalog != nil && len(RubricLevels(dimension, catalog)) == 0 {
+		return ErrInvalidDimensionKind(dimension.Name, pkg.MeasurementRubric, "neither its scale labels nor its catalogue version define a rubric")
+	}
+	return nil
+}
+
+func declareUnscaledDimension(dimension pkg.DimensionDeclaration, catalog *pkg.DimensionCatalog) error {
+	if dimension.Scale != nil {
+		return ErrInvalidDimensionKind(dimension.Name, dimension.MeasurementKind(), "a scale is only used by standard and rubric dimensions")
+	}
+	return nil
+}
+
+func declareChecklistDimension(dimension pkg.DimensionDeclaration, catalog *pkg.DimensionCatalog) error {
+	if err := declareUnscaledDimension(dimension, catalog); err != nil {
+		return err
+	}
+	if len(dimension.Criteria) == 0 {
+		return ErrInvalidDimensionKind(dimension.Name, pkg.MeasurementChecklist, "missing criteria")
+	}
+	seen := map[string]bool{}
+	for _, criterion := range dimension.Criteria {
+		if strings.TrimSpace(criterion) == "" {
+			return ErrInvalidDimensionKind(dimension.Name, pkg.MeasurementChecklist, "empty criterion")
+		}
+		if seen[criterion] {
+			return ErrInvalidDimensionKind(dimension.Name, pkg.MeasurementChecklist, fmt.Sprintf("criterion %q is listed more than once", criterion))
+		}
+		seen[criterion] = true
+	}
+	return nil
+}
+
+func decodeStandardScore(measurement pkg.Measurement) (pkg.MeasurementValue, error) {
+	return pkg.StandardScore{Score: measurement.Score}, nil
+}
+
+func decodeRubricSelection(measurement pkg.Measurement) (pkg.MeasurementValue, error) {
+	return measurement.RubricSelection, nil
+}
+
+func decodeChecklist(measurement pkg.Measurement) (pkg.MeasurementValue, error) {
+	return measurement.Checklist, nil
+}
+
+func decodePassFail(measurement pkg.Measurement) (pkg.MeasurementValue, error) {
+	return measurement.PassFail, nil
+}
+
+func validateStandardScore(dimension pkg.DimensionDeclaration, value pkg.MeasurementValue, catalog *pkg.DimensionCatalog) error {
+	score := value.(pkg.StandardScore).Score
+	if score == nil {
+		return errMissingMeasurementField("score")
+	}
+	if scale := ScoreScale(dimension, catalog); scale != nil {
+		return CheckScore(*scale, *score)
+	}
+	return nil
+}
+
+func validateRubricSelection(dimension pkg.DimensionDeclaration, value pkg.MeasurementValue, catalog *pkg.DimensionCatalog) error {
+	level := value.(pkg.RubricSelection).Level
+	if level == nil {
+		return errMissingMeasurementField("level")
+	}
+	if dimension.Scale != nil {
+		if err := CheckScore(*dimension.Scale, *level); err != nil {
+			return err
+		}
+	}
+	levels := RubricLevels(dimension, catalog)
+	if levels == nil && catalog == nil {
+		// Without the catalogue, only the levels of the scale are known.
+		return nil
+	}
+	if _, ok := levels[*level]; !ok {
+		return fmt.Errorf("level %d is not in the rubric", *level)
+	}
+	return nil
+}
+
+func validateChecklist(dimension pkg.DimensionDeclaration, value pkg.MeasurementValue, catalog *pkg.DimensionCatalog) error {
+	checklist := value.(pkg.Checklist).Checklist
+	if len(checklist) == 0 {
+		return errMissingMeasurementField("checklist")
+	}
+
+	declared := map[string]bool{}
+	for _, criterion := range dimension.Criteria {
+		declared[criterion] = true
+		if _, ok := checklist[criterion]; !ok {
+			return fmt.Errorf("criterion %q is not marked as met or not", criterion)
+		}
+	}
+	for criterion := range checklist {
+		if !declared[criterion] {
+			return fmt.Errorf("criterion %q is not declared by the dimension", criterion)
+		}
+	}
+	return nil
+}
+
+func validatePassFail(dimension pkg.DimensionDeclaration, value pkg.MeasurementValue, catalog *pkg.DimensionCatalog) error {
+	if value.(pkg.PassFail).Passed == nil {
+		return errMissingMeasurementField("passed")
+	}
+	return nil
+}
+
+func errMissingMeasurementField(field string) error {
+	return fmt.Errorf("missing %s", field)
+}
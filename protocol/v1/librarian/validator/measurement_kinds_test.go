@@ -0,0 +1,253 @@
+package validator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	"gopkg.in/yaml.v2"
+)
+
+const mockOnboardingDimension = `name: onboarding
+versions:
+  - version: 0.1.0
+`
+
+const mockGritDimension = `name: grit
+versions:
+  - version: 0.1.0
+    rubric:
+      1: Gave up at the first obstacle.
+      3: Kept at it with help.
+      4: Kept at it alone.
+      5: Kept at it and helped others.
+`
+
+// mockDimensionCatalog loads the official dimensions, along with an onboarding
+// dimension without a rubric and a grit dimension with one.
+func mockDimensionCatalog(t *testing.T) *pkg.DimensionCatalog {
+	root := t.TempDir()
+	writeMockFile(t, filepath.Join(root, "dimensions", "onboarding.yaml"), mockOnboardingDimension)
+	writeMockFile(t, filepath.Join(root, "dimensions", "grit.yaml"), mockGritDimension)
+
+	config, err := pkg.LoadConfig(root)
+	if err != nil {
+		t.Fatalf("failed to load config: %s", err)
+	}
+	catalog, err := pkg.LoadDimensionCatalog(config)
+	if err != nil {
+		t.Fatalf("failed to load dimension catalogue: %s", err)
+	}
+	return catalog
+}
+
+func mockKindsEvaluationFile() EvaluationFile {
+	file := EvaluationFile{Filepath: "evaluations/mentor.yaml"}
+	file.Definition.Meta.Dimensions = []pkg.DimensionDeclaration{
+		{Alias: "focus", Name: "grit", Version: "0.1.0", Kind: pkg.MeasurementRubric},
+		{Alias: "setup", Name: "onboarding", Version: "0.1.0", Kind: pkg.MeasurementChecklist, Criteria: []string{"dev environment", "first pull request"}},
+		{Alias: "gate", Name: "onboarding", Version: "0.1.0", Kind: pkg.MeasurementPassFail},
+	}
+	return file
+}
+
+func TestValidateDimensionKind(t *testing.T) {
+	catalog := mockDimensionCatalog(t)
+
+	for _, dimension := range mockKindsEvaluationFile().Definition.Meta.Dimensions {
+		if dimension.Name == "grit" {
+			if err := ValidateDimensionKind(dimension, catalog); err != nil {
+				t.Errorf("Expected %s to be valid, but got %s", dimension.Alias, err)
+			}
+			continue
+		}
+		if err := ValidateDimensionKind(dimension, nil); err != nil {
+			t.Errorf("Expected %s to be valid, but got %s", dimension.Alias, err)
+		}
+	}
+
+	cases := map[string]pkg.DimensionDeclaration{
+		"unknown kind":          {Name: "focus", Kind: "stars"},
+		"checklist criteria":    {Name: "onboarding", Kind: pkg.MeasurementChecklist},
+		"duplicate criterion":   {Name: "onboarding", Kind: pkg.MeasurementChecklist, Criteria: []string{"a", "a"}},
+		"criteria of a score":   {Name: "focus", Criteria: []string{"a"}},
+		"scaled gate":           {Name: "onboarding", Kind: pkg.MeasurementPassFail, Scale: &pkg.DimensionScale{Min: bound(0), Max: bound(1)}},
+		"rubric without levels": {Name: "onboarding", Version: "0.1.0", Kind: pkg.MeasurementRubric},
+	}
+	for name, dimension := range cases {
+		if err := ValidateDimensionKind(dimension, catalog); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestValidateMeasurementKinds(t *testing.T) {
+	catalog := mockDimensionCatalog(t)
+	file := mockKindsEvaluationFile()
+
+	level, outside, passed := 4, 9, true
+	valid := pkg.EvaluationRecord[pkg.Measurement]{ID: "e1", Measurements: []pkg.Measurement{
+		{Dimension: "focus", RubricSelection: pkg.RubricSelection{Level: &level}},
+		{Dimension: "setup", Checklist: pkg.Checklist{Checklist: map[string]bool{"dev environment": true, "first pull request": false}}},
+		{Dimension: "gate", PassFail: pkg.PassFail{Passed: &passed}},
+	}}
+	if err := ValidateMeasurements(file, valid, catalog); err != nil {
+		t.Fatalf("Expected the measurements to be valid, but got %s", err)
+	}
+
+	checklist, err := DecodeMeasurement(file.Definition.Meta.Dimensions[1], valid.Measurements[1])
+	if err != nil {
+		t.Fatalf("DecodeMeasurement failed: %s", err)
+	}
+	if score := checklist.ScaleScore(); score != nil || checklist.String() != "1 of 2 criteria met" {
+		t.Errorf("Expected 1 of 2 criteria met without a score, but got %v %s", score, checklist)
+	}
+
+	cases := map[string]pkg.Measurement{
+		"level outside of the rubric": {Dimension: "focus", RubricSelection: pkg.RubricSelection{Level: &outside}},
+		"score of a rubric":           {Dimension: "focus", Score: &level},
+		"unmarked criterion":          {Dimension: "setup", Checklist: pkg.Checklist{Checklist: map[string]bool{"dev environment": true}}},
+		"undeclared criterion":        {Dimension: "setup", Checklist: pkg.Checklist{Checklist: map[string]bool{"dev environment": true, "first pull request": true, "demo": true}}},
+		"gate without passed":         {Dimension: "gate"},
+		"field of another kind":       {Dimension: "gate", PassFail: pkg.PassFail{Passed: &passed}, RubricSelection: pkg.RubricSelection{Level: &level}},
+	}
+	for name, measurement := range cases {
+		record := pkg.EvaluationRecord[pkg.Measurement]{ID: "e1", Measurements: []pkg.Measurement{measurement}}
+		if err := ValidateMeasurements(file, record, catalog); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+const mockKindsRecords = `evaluations:
+  - id: e1
+    measurements:
+      - dimension: focus
+        level: 3
+      - dimension: setup
+        checklist:
+          dev environment: true
+          first pull request: true
+      - dimension: gate
+        passed: false
+`
+
+func TestMeasurementKindsRoundTrip(t *testing.T) {
+	file := mockKindsEvaluationFile()
+	if err := yaml.Unmarshal([]byte(mockKindsRecords), &file.Definition); err != nil {
+		t.Fatalf("failed to parse records: %s", err)
+	}
+	if err := ValidateMeasurements(file, file.Definition.Evaluations[0], mockDimensionCatalog(t)); err != nil {
+		t.Fatalf("Expected the decoded measurements to be valid, but got %s", err)
+	}
+
+	rendered := RenderEvaluationRecord(file.Definition.Evaluations[0])
+	for _, field := range []string{"level: 3", "first pull request: true", "passed: false"} {
+		if !strings.Contains(rendered, field) {
+			t.Errorf("Expected %q in the rendered record, but got:\n%s", field, rendered)
+		}
+	}
+
+	// Only the rubric level is a score on the scale of its dimension; the
+	// checklist and the gate are labelled but left out of the summaries.
+	measurements := collectMeasurements([]EvaluationFile{file}, false)["e1"]
+	summaries := summarizeDimensions(measurements)
+	if len(summaries) != 1 || summaries[0].Dimension != "grit" || summaries[0].Average != 3 {
+		t.Errorf("Expected only the grit level to be summarized, but got %+v", summaries)
+	}
+	if value := measurements[1].Value(); value != "2 of 2 criteria met" {
+		t.Errorf("Expected the checklist to be shown by its label, but got %s", value)
+	}
+	if value := measurements[2].Value(); value != "failed" {
+		t.Errorf("Expected the gate to be shown by its label, but got %s", value)
+	}
+}
+
+// mockTimeboxed is a measurement kind registered by the tests, recording the
+// minutes spent against the minutes planned.
+type mockTimeboxed struct {
+	Planned int `yaml:"planned"`
+	Spent   int `yaml:"spent"`
+}
+
+func (v mockTimeboxed) ScaleScore() *int {
+	return nil
+}
+
+func (v mockTimeboxed) String() string {
+	return fmt.Sprintf("%d of %d minutes", v.Spent, v.Planned)
+}
+
+func TestRegisterMeasurementKind(t *testing.T) {
+	timeboxed := MeasurementKind{
+		Field: "timebox",
+		Decode: func(measurement pkg.Measurement) (pkg.MeasurementValue, error) {
+			var value mockTimeboxed
+			err := DecodeMeasurementField(measurement, "timebox", &value)
+			return value, err
+		},
+		Validate: func(dimension pkg.DimensionDeclaration, value pkg.MeasurementValue, catalog *pkg.DimensionCatalog) error {
+			if value.(mockTimeboxed).Planned <= 0 {
+				return fmt.Errorf("missing planned minutes")
+			}
+			return nil
+		},
+	}
+	if err := RegisterMeasurementKind("timeboxed", timeboxed); err != nil {
+		t.Fatalf("RegisterMeasurementKind failed: %s", err)
+	}
+	defer delete(measurementKinds, "timeboxed")
+
+	if err := RegisterMeasurementKind("timeboxed", timeboxed); err == nil {
+		t.Errorf("Expected registering a kind twice to fail")
+	}
+	if err := RegisterMeasurementKind("scored", MeasurementKind{Field: "score", Decode: timeboxed.Decode}); err == nil {
+		t.Errorf("Expected registering a kind on the score field to fail")
+	}
+
+	file := EvaluationFile{Filepath: "evaluations/mentor.yaml"}
+	file.Definition.Meta.Dimensions = []pkg.DimensionDeclaration{
+		{Alias: "pacing", Name: "onboarding", Version: "0.1.0", Kind: "timeboxed"},
+		{Alias: "focus", Name: "focus", Version: "0.1.0"},
+	}
+	catalog := mockDimensionCatalog(t)
+
+	testCases := []struct {
+		name    string
+		records string
+		valid   bool
+	}{
+		{"registered kind", "evaluations:\n  - id: e1\n    measurements:\n      - dimension: pacing\n        timebox: {planned: 30, spent: 45}\n", true},
+		{"rejected by the kind", "evaluations:\n  - id: e1\n    measurements:\n      - dimension: pacing\n        timebox: {spent: 45}\n", false},
+		{"unknown field of the kind", "evaluations:\n  - id: e1\n    measurements:\n      - dimension: pacing\n        timebox: {planned: 30, late: true}\n", false},
+		{"field of the kind on another kind", "evaluations:\n  - id: e1\n    measurements:\n      - dimension: focus\n        score: 3\n        timebox: {planned: 30}\n", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := yaml.Unmarshal([]byte(tc.records), &file.Definition); err != nil {
+				t.Fatalf("failed to parse records: %s", err)
+			}
+			err := ValidateMeasurements(file, file.Definition.Evaluations[0], catalog)
+			if tc.valid && err != nil {
+				t.Errorf("Expected the measurement to be valid, but got %s", err)
+			}
+			if !tc.valid && err == nil {
+				t.Errorf("Expected the measurement to be rejected")
+			}
+		})
+	}
+
+	rendered := RenderEvaluationRecord(pkg.EvaluationRecord[pkg.Measurement]{
+		ID: "e2",
+		Measurements: []pkg.Measurement{{
+			Dimension: "pacing",
+			Fields:    map[string]interface{}{"timebox": map[string]int{"planned": 30, "spent": 20}},
+		}},
+	})
+	if !strings.Contains(rendered, "timebox:") || !strings.Contains(rendered, "planned: 30") {
+		t.Errorf("Expected the timebox in the rendered record, but got:\n%s", rendered)
+	}
+}
@@ -0,0 +1,19 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeMockFile writes the content to the path, creating its directories, and
+// returns the path.
+func writeMockFile(t *testing.T, path string, content string) string {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create directory: %s", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %s", path, err)
+	}
+	return path
+}
@@ -0,0 +1,296 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+// ReportPeriod defines the span of time covered by a progress report.
+type ReportPeriod string
+
+const (
+	// ReportByWeek covers a week, from Monday to Sunday.
+	ReportByWeek ReportPeriod = "week"
+	// ReportByMonth covers a calendar month.
+	ReportByMonth ReportPeriod = "month"
+	// ReportByQuarter covers a calendar quarter.
+	ReportByQuarter ReportPeriod = "quarter"
+)
+
+// ParseReportPeriod parses a report period name.
+func ParseReportPeriod(value string) (ReportPeriod, error) {
+	switch period := ReportPeriod(value); period {
+	case ReportByWeek, ReportByMonth, ReportByQuarter:
+		return period, nil
+	}
+	return "", fmt.Errorf("unsupported report period %q: expected week, month or quarter", value)
+}
+
+// Range returns the first and last day of the period containing the date.
+func (p ReportPeriod) Range(date time.Time) (time.Time, time.Time) {
+	date = pkg.DateOf(date)
+	switch p {
+	case ReportByWeek:
+		start := date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 6)
+	case ReportByQuarter:
+		start := time.Date(date.Year(), ((date.Month()-1)/3)*3+1, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 3, -1)
+	default:
+		start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, -1)
+	}
+}
+
+type (
+	// ProgressReport summarizes the DSUs and evaluations of a period.
+	ProgressReport struct {
+		Period ReportPeriod
+		Start  time.Time
+		End    time.Time
+
+		// Entries lists the DSU entries of the period, sorted by date.
+		Entries []ReportEntry
+		// Dimensions summarizes the evaluation scores of the period per dimension.
+		Dimensions []DimensionSummary
+		// OpenBlockers lists the blockers still reported by the last DSU of the period.
+		OpenBlockers []Blocker
+		// MissingDays lists the working days of the period without a DSU.
+		MissingDays []time.Time
+	}
+
+	// ReportEntry is a DSU entry along with its evaluations.
+	ReportEntry struct {
+		pkg.DSUReport
+		Measurements []ReportMeasurement
+	}
+
+	// ReportMeasurement is a measurement of a DSU entry, with its dimension
+	// alias resolved to the dimension name. Its score is the score of its value
+	// on the scale of the dimension, nil for checklists and pass/fail gates,
+	// which are only labelled.
+	ReportMeasurement struct {
+		pkg.Measurement
+		File string
+		// Evaluator is the name of who made the measurement.
+		Evaluator string
+		// Label is the label of the level of the score on the dimension's scale, if any.
+		Label string
+	}
+
+	// DimensionSummary aggregates the scores of a dimension.
+	DimensionSummary struct {
+		Dimension string
+		Count     int
+		Average   float64
+		Min       int
+		Max       int
+	}
+)
+
+// BuildProgressReport builds the report of the period containing the date.
+// Missing days are only counted until today. Meta evaluations of the mentor
+// are kept out of the scores, see BuildMentorFeedbackReport.
+func BuildProgressReport(dsuFiles []DSUFile, evaluationFiles []EvaluationFile, calendar *pkg.Calendar, period ReportPeriod, date time.Time, today time.Time) ProgressReport {
+	start, end := period.Range(date)
+	report := ProgressReport{
+		Period:       period,
+		Start:        start,
+		End:          end,
+		Entries:      []ReportEntry{},
+		Dimensions:   []DimensionSummary{},
+		OpenBlockers: []Blocker{},
+		MissingDays:  []time.Time{},
+	}
+
+	entries := []pkg.DSUReport{}
+	for _, file := range dsuFiles {
+		for _, entry := range file.Definition.Content {
+			if InDateRange(entry.Datetime, start, end) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Datetime.Before(entries[j].Datetime)
+	})
+
+	measurements := collectMeasurements(evaluationFiles, false)
+
+	scored := []ReportMeasurement{}
+	for _, entry := range entries {
+		reportEntry := ReportEntry{DSUReport: entry, Measurements: measurements[entry.ID]}
+		report.Entries = append(report.Entries, reportEntry)
+		scored = append(scored, reportEntry.Measurements...)
+	}
+	report.Dimensions = summarizeDimensions(scored)
+
+	for _, blocker := range TrackBlockers(entries, DefaultBlockerSimilarity) {
+		if blocker.Open {
+			report.OpenBlockers = append(report.OpenBlockers, blocker)
+		}
+	}
+
+	until := end
+	if today.Before(until) {
+		until = today
+	}
+	report.MissingDays = append(report.MissingDays, FindGaps(entries, calendar, start, until)...)
+
+	return report
+}
+
+// collectMeasurements maps training IDs to their measurements, taken from
+// either the meta evaluations or the other evaluation files.
+func collectMeasurements(evaluationFiles []EvaluationFile, meta bool) map[string][]ReportMeasurement {
+	measurements := map[string][]ReportMeasurement{}
+	for _, file := range evaluationFiles {
+		if file.IsMeta() != meta {
+			continue
+		}
+		for _, record := range file.Definition.Evaluations {
+			for _, measurement := range record.Measurements {
+				reportMeasurement := ReportMeasurement{
+					Measurement: measurement,
+					File:        file.Filepath,
+					Evaluator:   file.EvaluatorName(),
+				}
+				if dimension := file.Dimension(measurement.Dimension); dimension != nil {
+					reportMeasurement.Dimension = dimension.Name
+					if value, err := DecodeMeasurement(*dimension, measurement); err == nil {
+						reportMeasurement.Score = value.ScaleScore()
+						if dimension.MeasurementKind() != pkg.MeasurementStandard {
+							reportMeasurement.Label = value.String()
+						}
+					}
+					if reportMeasurement.Score != nil && dimension.Scale.Label(*reportMeasurement.Score) != "" {
+						reportMeasurement.Label = dimension.Scale.Label(*reportMeasurement.Score)
+					}
+				}
+				measurements[record.ID] = append(measurements[record.ID], reportMeasurement)
+			}
+		}
+	}
+	return measurements
+}
+
+// Value returns the score of the measurement with the label of its level, or
+// the label alone for the kinds that are not scored.
+func (m ReportMeasurement) Value() string {
+	if m.Score == nil {
+		if m.Label != "" {
+			return m.Label
+		}
+		return "-"
+	}
+	if m.Label != "" {
+		return fmt.Sprintf("%d (%s)", *m.Score, m.Label)
+	}
+	return fmt.Sprint(*m.Score)
+}
+
+// summarizeDimensions aggregates the scores of the measurements per dimension, sorted by dimension.
+func summarizeDimensions(measurements []ReportMeasurement) []DimensionSummary {
+	summaries := map[string]*DimensionSummary{}
+	for _, measurement := range measurements {
+		if measurement.Score == nil {
+			continue
+		}
+		score := *measurement.Score
+		summary, ok := summaries[measurement.Dimension]
+		if !ok {
+			summary = &DimensionSummary{Dimension: measurement.Dimension, Min: score, Max: score}
+			summaries[measurement.Dimension] = summary
+		}
+		summary.Average = (summary.Average*float64(summary.Count) + float64(score)) / float64(summary.Count+1)
+		summary.Count++
+		if score < summary.Min {
+			summary.Min = score
+		}
+		if score > summary.Max {
+			summary.Max = score
+		}
+	}
+
+	dimensions := []DimensionSummary{}
+	for _, summary := range summaries {
+		dimensions = append(dimensions, *summary)
+	}
+	sort.Slice(dimensions, func(i, j int) bool {
+		return dimensions[i].Dimension < dimensions[j].Dimension
+	})
+	return dimensions
+}
+
+type (
+	// MentorFeedbackReport summarizes the meta evaluations of the mentor's
+	// teaching over the DSU entries of a period.
+	MentorFeedbackReport struct {
+		Period ReportPeriod
+		Start  time.Time
+		End    time.Time
+
+		// Entries lists the rated DSU entries of the period, sorted by date.
+		Entries []MentorFeedbackEntry
+		// Dimensions summarizes the ratings of the period per dimension.
+		Dimensions []DimensionSummary
+	}
+
+	// MentorFeedbackEntry is the feedback on the teaching of a DSU entry.
+	MentorFeedbackEntry struct {
+		ID       string
+		Datetime time.Time
+		// Mentor is the name of the mentor whose teaching is rated.
+		Mentor       string
+		Measurements []ReportMeasurement
+	}
+)
+
+// BuildMentorFeedbackReport builds the mentor-feedback report of the period
+// containing the date, from the meta evaluations only.
+func BuildMentorFeedbackReport(dsuFiles []DSUFile, evaluationFiles []EvaluationFile, period ReportPeriod, date time.Time) MentorFeedbackReport {
+	start, end := period.Range(date)
+	report := MentorFeedbackReport{
+		Period:     period,
+		Start:      start,
+		End:        end,
+		Entries:    []MentorFeedbackEntry{},
+		Dimensions: []DimensionSummary{},
+	}
+
+	mentors := map[string]string{}
+	for _, file := range evaluationFiles {
+		if !file.IsMeta() {
+			continue
+		}
+		for _, record := range file.Definition.Evaluations {
+			mentors[record.ID] = file.MentorName()
+		}
+	}
+	measurements := collectMeasurements(evaluationFiles, true)
+
+	rated := []ReportMeasurement{}
+	for _, file := range dsuFiles {
+		for _, entry := range file.Definition.Content {
+			if !InDateRange(entry.Datetime, start, end) || len(measurements[entry.ID]) == 0 {
+				continue
+			}
+			report.Entries = append(report.Entries, MentorFeedbackEntry{
+				ID:           entry.ID,
+				Datetime:     entry.Datetime,
+				Mentor:       mentors[entry.ID],
+				Measurements: measurements[entry.ID],
+			})
+			rated = append(rated, measurements[entry.ID]...)
+		}
+	}
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		return report.Entries[i].Datetime.Before(report.Entries[j].Datetime)
+	})
+	report.Dimensions = summarizeDimensions(rated)
+
+	return report
+}
@@ -0,0 +1,99 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+func TestReportPeriodRange(t *testing.T) {
+	date := time.Date(2024, 8, 15, 0, 0, 0, 0, time.UTC) // Thursday
+	cases := []struct {
+		period     ReportPeriod
+		start, end string
+	}{
+		{ReportByWeek, "2024-08-12", "2024-08-18"},
+		{ReportByMonth, "2024-08-01", "2024-08-31"},
+		{ReportByQuarter, "2024-07-01", "2024-09-30"},
+	}
+
+	for _, tc := range cases {
+		start, end := tc.period.Range(date)
+		if start.Format(pkg.DateLayout) != tc.start || end.Format(pkg.DateLayout) != tc.end {
+			t.Errorf("%s: expected %s → %s, but got %s → %s", tc.period, tc.start, tc.end, start.Format(pkg.DateLayout), end.Format(pkg.DateLayout))
+		}
+	}
+}
+
+func TestBuildProgressReport(t *testing.T) {
+	calendar := mockCalendar(t)
+
+	entries := mockDatedEntries("2024-07-01", "2024-07-02", "2024-07-04", "2024-07-08")
+	entries[1].Blockers = "- Waiting for database access\n"
+	entries[2].Blockers = "- Waiting for database access\n"
+	dsuFile := DSUFile{Filepath: "training/dsu-reports.yaml"}
+	dsuFile.Definition.Content = entries
+
+	two, three := 2, 3
+	evaluationFile := EvaluationFile{Filepath: "evaluations/self.yaml"}
+	evaluationFile.Definition.Meta.Dimensions = []pkg.DimensionDeclaration{{Alias: "f", Name: "focus", Version: "0.1.0"}}
+	evaluationFile.Definition.Evaluations = []pkg.EvaluationRecord[pkg.Measurement]{
+		{ID: "2024-07-01", Measurements: []pkg.Measurement{{Dimension: "f", Score: &two}}},
+		{ID: "2024-07-02", Measurements: []pkg.Measurement{{Dimension: "focus", Score: &three}}},
+		{ID: "2024-07-08", Measurements: []pkg.Measurement{{Dimension: "focus", Score: &three}}},
+	}
+
+	today := time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC)
+	report := BuildProgressReport([]DSUFile{dsuFile}, []EvaluationFile{evaluationFile}, calendar, ReportByWeek, today, today)
+
+	if len(report.Entries) != 3 {
+		t.Fatalf("Expected 3 entries in the week, but got %d", len(report.Entries))
+	}
+	if len(report.Dimensions) != 1 || report.Dimensions[0].Dimension != "focus" || report.Dimensions[0].Count != 2 || report.Dimensions[0].Average != 2.5 {
+		t.Errorf("Expected focus averaging 2.5 over 2 scores, but got %+v", report.Dimensions)
+	}
+	if len(report.OpenBlockers) != 1 || report.OpenBlockers[0].Occurrences != 2 {
+		t.Errorf("Expected the database access blocker to be open, but got %+v", report.OpenBlockers)
+	}
+	if len(report.MissingDays) != 2 || report.MissingDays[0].Format(pkg.DateLayout) != "2024-07-03" || report.MissingDays[1].Format(pkg.DateLayout) != "2024-07-05" {
+		t.Errorf("Expected 2024-07-03 and 2024-07-05 to be missing, but got %v", report.MissingDays)
+	}
+}
+
+func TestBuildMentorFeedbackReport(t *testing.T) {
+	dsuFile := DSUFile{Filepath: "training/dsu-reports.yaml"}
+	dsuFile.Definition.Content = mockDatedEntries("2024-07-01", "2024-07-02", "2024-07-08")
+
+	two, four := 2, 4
+	selfFile := EvaluationFile{Filepath: "evaluations/self.yaml"}
+	selfFile.Definition.Meta.Dimensions = []pkg.DimensionDeclaration{{Alias: "focus", Name: "focus", Version: "0.1.0"}}
+	selfFile.Definition.Evaluations = []pkg.EvaluationRecord[pkg.Measurement]{
+		{ID: "2024-07-01", Measurements: []pkg.Measurement{{Dimension: "focus", Score: &two}}},
+	}
+
+	metaFile := EvaluationFile{Filepath: "evaluations/meta/self.yaml"}
+	metaFile.Definition.Tomegg.Subtype = pkg.EvaluationSubtypeMeta
+	metaFile.Definition.Meta.Mentor = &pkg.Evaluator{Name: "Ada"}
+	metaFile.Definition.Meta.Dimensions = []pkg.DimensionDeclaration{{Alias: "t", Name: "teaching", Version: "0.1.0"}}
+	metaFile.Definition.Evaluations = []pkg.EvaluationRecord[pkg.Measurement]{
+		{ID: "2024-07-02", Measurements: []pkg.Measurement{{Dimension: "t", Score: &four}}},
+		{ID: "2024-07-08", Measurements: []pkg.Measurement{{Dimension: "t", Score: &two}}},
+	}
+
+	date := time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC)
+	files := []EvaluationFile{selfFile, metaFile}
+
+	feedback := BuildMentorFeedbackReport([]DSUFile{dsuFile}, files, ReportByWeek, date)
+	if len(feedback.Entries) != 1 || feedback.Entries[0].ID != "2024-07-02" || feedback.Entries[0].Mentor != "Ada" {
+		t.Fatalf("Expected the teaching of 2024-07-02 by Ada to be rated, but got %+v", feedback.Entries)
+	}
+	if len(feedback.Dimensions) != 1 || feedback.Dimensions[0].Dimension != "teaching" || feedback.Dimensions[0].Average != 4 {
+		t.Errorf("Expected teaching averaging 4, but got %+v", feedback.Dimensions)
+	}
+
+	progress := BuildProgressReport([]DSUFile{dsuFile}, files, mockCalendar(t), ReportByWeek, date, date)
+	if len(progress.Dimensions) != 1 || progress.Dimensions[0].Dimension != "focus" {
+		t.Errorf("Expected meta evaluations to be kept out of the progress report, but got %+v", progress.Dimensions)
+	}
+}
@@ -0,0 +1,291 @@
+package validator
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+// ScoreTrend is the direction the scores of a dimension are heading.
+type ScoreTrend string
+
+const (
+	// TrendUp means the scores are improving.
+	TrendUp ScoreTrend = "up"
+	// TrendDown means the scores are declining.
+	TrendDown ScoreTrend = "down"
+	// TrendFlat means the scores are steady, or too few to tell.
+	TrendFlat ScoreTrend = "flat"
+)
+
+// DefaultRollingWindow is the number of scores the rolling average spans.
+const DefaultRollingWindow = 5
+
+// trendThreshold is the least change of score per week, as fitted over the
+// whole series, that counts as a trend.
+const trendThreshold = 0.05
+
+// week is the unit of time slopes are fitted over.
+const week = 7 * 24 * time.Hour
+
+type (
+	// ScoreQuery selects the scores of a time series.
+	ScoreQuery struct {
+		// Dimension restricts the series to a dimension name, if set.
+		Dimension string
+		// Evaluator restricts the scores to an evaluator, if set.
+		Evaluator string
+		Since     time.Time
+		Until     time.Time
+		// Window is the number of scores the rolling average spans.
+		Window int
+	}
+
+	// ScoreSeries is the time series of the scores an evaluator gave a dimension.
+	ScoreSeries struct {
+		Dimension string       `json:"dimension"`
+		Evaluator string       `json:"evaluator"`
+		Points    []ScorePoint `json:"points"`
+		Average   float64      `json:"average"`
+		// Slope is the change of score per week, fitted over the dates of the scores.
+		Slope float64    `json:"slope"`
+		Trend ScoreTrend `json:"trend"`
+		// Scale is the scale the evaluation files declare for the dimension, if any.
+		Scale *pkg.DimensionScale `json:"scale,omitempty"`
+	}
+
+	// ScorePoint is a score of a DSU entry, dated by the entry.
+	ScorePoint struct {
+		Date      time.Time `json:"date"`
+		ID        string    `json:"id"`
+		Score     int       `json:"score"`
+		Evaluator string    `json:"evaluator"`
+		// RollingAverage averages the score with the ones before it, within the window.
+		RollingAverage float64 `json:"rolling_average"`
+	}
+)
+
+// BuildScoreSeries joins the measurements of the evaluation files to the DSU
+// entries they evaluate, and returns a time series per dimension and evaluator,
+// sorted by dimension then evaluator. Meta evaluations of the mentor and measurements without a score
+// or a known DSU entry are left out.
+func BuildScoreSeries(dsuFiles []DSUFile, evaluationFiles []EvaluationFile, query ScoreQuery) []ScoreSeries {
+	window := query.Window
+	if window <= 0 {
+		window = DefaultRollingWindow
+	}
+
+	dates := map[string]time.Time{}
+	for _, file := range dsuFiles {
+		for _, entry := range file.Definition.Content {
+			if !entry.Datetime.IsZero() {
+				dates[entry.ID] = entry.Datetime
+			}
+		}
+	}
+
+	files := evaluationFiles
+	if query.Evaluator != "" {
+		files = []EvaluationFile{}
+		for _, file := range evaluationFiles {
+			if file.EvaluatedBy(query.Evaluator) {
+				files = append(files, file)
+			}
+		}
+	}
+
+	scales := map[string]*pkg.DimensionScale{}
+	for _, file := range files {
+		for _, dimension := range file.Definition.Meta.Dimensions {
+			if dimension.Scale != nil && scales[dimension.Name] == nil {
+				scales[dimension.Name] = dimension.Scale
+			}
+		}
+	}
+
+	// seriesKey identifies the series of an evaluator on a dimension.
+	type seriesKey struct {
+		dimension string
+		evaluator string
+	}
+
+	points := map[seriesKey][]ScorePoint{}
+	for id, measurements := range collectMeasurements(files, false) {
+		date, ok := dates[id]
+		if !ok || !InDateRange(date, query.Since, query.Until) {
+			continue
+		}
+		for _, measurement := range measurements {
+			if measurement.Score == nil {
+				continue
+			}
+			if query.Dimension != "" && !strings.EqualFold(measurement.Dimension, query.Dimension) {
+				continue
+			}
+			key := seriesKey{dimension: measurement.Dimension, evaluator: measurement.Evaluator}
+			points[key] = append(points[key], ScorePoint{
+				Date:      date,
+				ID:        id,
+				Score:     *measurement.Score,
+				Evaluator: measurement.Evaluator,
+			})
+		}
+	}
+
+	series := []ScoreSeries{}
+	for key, seriesPoints := range points {
+		sort.SliceStable(seriesPoints, func(i, j int) bool {
+			if !seriesPoints[i].Date.Equal(seriesPoints[j].Date) {
+				return seriesPoints[i].Date.Before(seriesPoints[j].Date)
+			}
+			return seriesPoints[i].ID < seriesPoints[j].ID
+		})
+
+		sum := 0
+		weeks := make([]float64, len(seriesPoints))
+		scores := make([]float64, len(seriesPoints))
+		for i := range seriesPoints {
+			sum += seriesPoints[i].Score
+			seriesPoints[i].RollingAverage = rollingAverage(seriesPoints, i, window)
+			weeks[i] = float64(seriesPoints[i].Date.Sub(seriesPoints[0].Date)) / float64(week)
+			scores[i] = float64(seriesPoints[i].Score)
+		}
+
+		slope := fitSlope(weeks, scores)
+		series = append(series, ScoreSeries{
+			Dimension: key.dimension,
+			Evaluator: key.evaluator,
+			Points:    seriesPoints,
+			Average:   float64(sum) / float64(len(seriesPoints)),
+			Slope:     slope,
+			Trend:     trendOf(slope),
+			Scale:     scales[key.dimension],
+		})
+	}
+	sort.Slice(series, func(i, j int) bool {
+		if series[i].Dimension != series[j].Dimension {
+			return series[i].Dimension < series[j].Dimension
+		}
+		return series[i].Evaluator < series[j].Evaluator
+	})
+	return series
+}
+
+// rollingAverage averages the score at index i with up to window-1 scores before it.
+func rollingAverage(points []ScorePoint, i int, window int) float64 {
+	start := i - window + 1
+	if start < 0 {
+		start = 0
+	}
+	sum := 0
+	for _, point := range points[start : i+1] {
+		sum += point.Score
+	}
+	return float64(sum) / float64(i+1-start)
+}
+
+// fitSlope fits a least squares line through the points (xs[i], ys[i]) and
+// returns its slope, 0 when the xs do not vary.
+func fitSlope(xs []float64, ys []float64) float64 {
+	n := float64(len(xs))
+	if n < 2 {
+		return 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, x := range xs {
+		y := ys[i]
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+func trendOf(slope float64) ScoreTrend {
+	switch {
+	case slope >= trendThreshold:
+		return TrendUp
+	case slope <= -trendThreshold:
+		return TrendDown
+	}
+	return TrendFlat
+}
+
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws the values as a line of bars, scaled from min to max.
+func Sparkline(values []float64, min float64, max float64) string {
+	var line strings.Builder
+	for _, value := range values {
+		level := 0
+		if max > min {
+			level = int(math.Round((value - min) / (max - min) * float64(len(sparkBars)-1)))
+		}
+		if level < 0 {
+			level = 0
+		}
+		if level >= len(sparkBars) {
+			level = len(sparkBars) - 1
+		}
+		line.WriteRune(sparkBars[level])
+	}
+	return line.String()
+}
+
+// Scores returns the scores of the series, in order.
+func (s ScoreSeries) Scores() []float64 {
+	scores := make([]float64, len(s.Points))
+	for i, point := range s.Points {
+		scores[i] = float64(point.Score)
+	}
+	return scores
+}
+
+// Range returns the bounds of the scale of the series, or the lowest and
+// highest score of the series for the sides the scale leaves unbounded.
+func (s ScoreSeries) Range() (int, int) {
+	min, max := 0, 0
+	for i, point := range s.Points {
+		if i == 0 || point.Score < min {
+			min = point.Score
+		}
+		if i == 0 || point.Score > max {
+			max = point.Score
+		}
+	}
+	if s.Scale != nil {
+		scaleMin, scaleMax := s.Scale.Range()
+		if scaleMin != nil {
+			min = *scaleMin
+		}
+		if scaleMax != nil {
+			max = *scaleMax
+		}
+	}
+	return min, max
+}
+
+// Start returns the date of the first score of the series.
+func (s ScoreSeries) Start() time.Time {
+	if len(s.Points) == 0 {
+		return time.Time{}
+	}
+	return pkg.DateOf(s.Points[0].Date)
+}
+
+// End returns the date of the last score of the series.
+func (s ScoreSeries) End() time.Time {
+	if len(s.Points) == 0 {
+		return time.Time{}
+	}
+	return pkg.DateOf(s.Points[len(s.Points)-1].Date)
+}
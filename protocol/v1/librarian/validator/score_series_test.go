@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+func TestBuildScoreSeries(t *testing.T) {
+	dsuFile := DSUFile{Filepath: "training/dsu-reports.yaml"}
+	dsuFile.Definition.Content = mockDatedEntries("2024-07-01", "2024-07-02", "2024-07-03", "2024-07-04")
+
+	scores := []int{1, 2, 4, 5}
+	evaluationFile := EvaluationFile{Filepath: "evaluations/self.yaml"}
+	evaluationFile.Definition.Meta.Dimensions = []pkg.DimensionDeclaration{
+		{Alias: "f", Name: "focus", Version: "0.1.0", Scale: &pkg.DimensionScale{Min: bound(1), Max: bound(5)}},
+	}
+	for i, id := range []string{"2024-07-04", "2024-07-01", "2024-07-02", "2024-07-03"} {
+		score := scores[(i+3)%4]
+		evaluationFile.Definition.Evaluations = append(evaluationFile.Definition.Evaluations,
+			pkg.EvaluationRecord[pkg.Measurement]{ID: id, Measurements: []pkg.Measurement{{Dimension: "f", Score: &score}}})
+	}
+	evaluationFile.Definition.Evaluations = append(evaluationFile.Definition.Evaluations,
+		pkg.EvaluationRecord[pkg.Measurement]{ID: "unknown", Measurements: []pkg.Measurement{{Dimension: "f", Score: &scores[0]}}})
+
+	series := BuildScoreSeries([]DSUFile{dsuFile}, []EvaluationFile{evaluationFile}, ScoreQuery{Window: 2})
+	if len(series) != 1 || series[0].Dimension != "focus" {
+		t.Fatalf("Expected a single focus series, but got %+v", series)
+	}
+
+	focus := series[0]
+	if len(focus.Points) != 4 {
+		t.Fatalf("Expected the scores of the 4 known entries, but got %d", len(focus.Points))
+	}
+	for i, score := range scores {
+		if focus.Points[i].Score != score {
+			t.Errorf("Expected score %d at %d, sorted by date, but got %d", score, i, focus.Points[i].Score)
+		}
+	}
+	if focus.Points[2].RollingAverage != 3 {
+		t.Errorf("Expected a rolling average of 3 over 2 scores, but got %.2f", focus.Points[2].RollingAverage)
+	}
+	if focus.Average != 3 || focus.Trend != TrendUp {
+		t.Errorf("Expected an upward trend averaging 3, but got %.2f %s", focus.Average, focus.Trend)
+	}
+	if min, max := focus.Range(); min != 1 || max != 5 {
+		t.Errorf("Expected the range of the scale, but got %d to %d", min, max)
+	}
+
+	since := time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC)
+	series = BuildScoreSeries([]DSUFile{dsuFile}, []EvaluationFile{evaluationFile}, ScoreQuery{Since: since, Dimension: "grit"})
+	if len(series) != 0 {
+		t.Errorf("Expected no series for an unscored dimension, but got %+v", series)
+	}
+	series = BuildScoreSeries([]DSUFile{dsuFile}, []EvaluationFile{evaluationFile}, ScoreQuery{Since: since})
+	if len(series) != 1 || len(series[0].Points) != 2 {
+		t.Errorf("Expected the 2 scores since %s, but got %+v", since.Format(pkg.DateLayout), series)
+	}
+}
+
+func TestBuildScoreSeriesPerEvaluator(t *testing.T) {
+	dsuFile := DSUFile{Filepath: "training/dsu-reports.yaml"}
+	dsuFile.Definition.Content = mockDatedEntries("2024-07-01", "2024-07-02", "2024-07-03", "2024-07-29")
+
+	evaluationFile := func(path string, scores map[string]int) EvaluationFile {
+		file := EvaluationFile{Filepath: path}
+		file.Definition.Meta.Dimensions = []pkg.DimensionDeclaration{{Alias: "f", Name: "focus", Version: "0.1.0"}}
+		for _, id := range []string{"2024-07-01", "2024-07-02", "2024-07-03", "2024-07-29"} {
+			score, ok := scores[id]
+			if !ok {
+				continue
+			}
+			file.Definition.Evaluations = append(file.Definition.Evaluations,
+				pkg.EvaluationRecord[pkg.Measurement]{ID: id, Measurements: []pkg.Measurement{{Dimension: "f", Score: &score}}})
+		}
+		return file
+	}
+
+	// By index the self scores climb then fall back, but the fall comes four
+	// weeks after the climb, so over time they decline.
+	self := evaluationFile("evaluations/self.yaml", map[string]int{"2024-07-01": 3, "2024-07-02": 4, "2024-07-03": 5, "2024-07-29": 2})
+	mentor := evaluationFile("evaluations/mentor.yaml", map[string]int{"2024-07-01": 1, "2024-07-03": 1})
+
+	series := BuildScoreSeries([]DSUFile{dsuFile}, []EvaluationFile{self, mentor}, ScoreQuery{Window: 2})
+	if len(series) != 2 || series[0].Evaluator != "mentor.yaml" || series[1].Evaluator != SelfEvaluator {
+		t.Fatalf("Expected a focus series for the mentor and for self, but got %+v", series)
+	}
+
+	if len(series[0].Points) != 2 || series[0].Trend != TrendFlat || series[0].Average != 1 {
+		t.Errorf("Expected the mentor's steady scores only, but got %+v", series[0])
+	}
+	if series[1].Points[1].RollingAverage != 3.5 {
+		t.Errorf("Expected the rolling average to span self scores only, but got %.2f", series[1].Points[1].RollingAverage)
+	}
+	if series[1].Trend != TrendDown {
+		t.Errorf("Expected self scores to decline over time, but got %s (%+.2f per week)", series[1].Trend, series[1].Slope)
+	}
+}
+
+func TestFitSlope(t *testing.T) {
+	testCases := []struct {
+		xs       []float64
+		ys       []float64
+		expected float64
+	}{
+		{[]float64{0, 1, 2}, []float64{1, 2, 3}, 1},
+		{[]float64{0, 2, 4}, []float64{1, 2, 3}, 0.5},
+		{[]float64{0}, []float64{5}, 0},
+		{[]float64{1, 1}, []float64{1, 5}, 0},
+	}
+
+	for _, tc := range testCases {
+		if slope := fitSlope(tc.xs, tc.ys); slope != tc.expected {
+			t.Errorf("Expected a slope of %.2f through %v, %v, but got %.2f", tc.expected, tc.xs, tc.ys, slope)
+		}
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if line := Sparkline([]float64{1, 3, 5}, 1, 5); line != "▁▅█" {
+		t.Errorf("Expected ▁▅█, but got %s", line)
+	}
+	if line := Sparkline([]float64{2, 2}, 2, 2); line != "▁▁" {
+		t.Errorf("Expected a flat line, but got %s", line)
+	}
+}
@@ -0,0 +1,396 @@
+package validator
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	"gopkg.in/yaml.v2"
+)
+
+// signaturePrefix separates signatures of evaluations from other uses of the key.
+const signaturePrefix = "tome.gg evaluation signature v1\n"
+
+// CanonicalEvaluation returns the canonical form of an evaluation file, or of
+// the records with the given IDs: the YAML content without meta.signatures,
+// with its keys sorted, so that comments and formatting can change without
+// breaking the signatures. Records are signed along with the tomegg header,
+// the evaluator and the declarations of the dimensions they measure, so that
+// they cannot be passed off as another evaluator's or another kind of file's.
+func CanonicalEvaluation(fileBytes []byte, records []string) ([]byte, error) {
+	doc := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(fileBytes, &doc); err != nil {
+		return nil, err
+	}
+
+	meta, _ := doc["meta"].(map[interface{}]interface{})
+	if meta != nil {
+		delete(meta, "signatures")
+	}
+
+	if len(records) == 0 {
+		return yaml.Marshal(doc)
+	}
+
+	wanted := map[string]bool{}
+	for _, id := range records {
+		wanted[id] = true
+	}
+
+	found := map[string]bool{}
+	aliases := map[string]bool{}
+	evaluations, _ := doc["evaluations"].([]interface{})
+	signed := []interface{}{}
+	for _, evaluation := range evaluations {
+		record, _ := evaluation.(map[interface{}]interface{})
+		id := fmt.Sprint(record["id"])
+		if record == nil || !wanted[id] {
+			continue
+		}
+		found[id] = true
+		signed = append(signed, record)
+
+		measurements, _ := record["measurements"].([]interface{})
+		for _, measurement := range measurements {
+			if measurement, ok := measurement.(map[interface{}]interface{}); ok {
+				aliases[fmt.Sprint(measurement["dimension"])] = true
+			}
+		}
+	}
+	for _, id := range records {
+		if !found[id] {
+			return nil, ErrTrainingNotFound(id)
+		}
+	}
+
+	dimensions := []interface{}{}
+	declared, _ := meta["dimensions"].([]interface{})
+	for _, dimension := range declared {
+		if dimension, ok := dimension.(map[interface{}]interface{}); ok && aliases[fmt.Sprint(dimension["alias"])] {
+			dimensions = append(dimensions, dimension)
+		}
+	}
+
+	return yaml.Marshal(map[string]interface{}{
+		"tomegg":      doc["tomegg"],
+		"evaluator":   meta["evaluator"],
+		"dimensions":  dimensions,
+		"evaluations": signed,
+	})
+}
+
+// SignEvaluation signs the canonical form of an evaluation file, or of the
+// records with the given IDs.
+func SignEvaluation(fileBytes []byte, key ed25519.PrivateKey, records []string) (pkg.Signature, error) {
+	canonical, err := CanonicalEvaluation(fileBytes, records)
+	if err != nil {
+		return pkg.Signature{}, err
+	}
+
+	return pkg.Signature{
+		Algorithm: pkg.SignatureAlgorithm,
+		PublicKey: PublicKeyOf(key),
+		Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(key, append([]byte(signaturePrefix), canonical...))),
+		Records:   records,
+	}, nil
+}
+
+// VerifySignature checks that the signature was made with one of the trusted
+// keys, and still matches the content it signs. The public key recorded in the
+// signature is only trusted when it is one of the trusted keys, since whoever
+// edits the file can record any key.
+func VerifySignature(fileBytes []byte, signature pkg.Signature, trusted []string) error {
+	if err := CheckSignature(fileBytes, signature); err != nil {
+		return err
+	}
+	for _, key := range trusted {
+		if key == signature.PublicKey {
+			return nil
+		}
+	}
+	return ErrUntrustedSignature(signature)
+}
+
+// CheckSignature checks that the signature still matches the content it signs,
+// whoever made it; see VerifySignature.
+func CheckSignature(fileBytes []byte, signature pkg.Signature) error {
+	if signature.Algorithm != pkg.SignatureAlgorithm {
+		return ErrInvalidSignature(signature, fmt.Sprintf("unsupported algorithm %q", signature.Algorithm))
+	}
+
+	publicKey, err := base64.StdEncoding.DecodeString(signature.PublicKey)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return ErrInvalidSignature(signature, "malformed public key")
+	}
+	value, err := base64.StdEncoding.DecodeString(signature.Value)
+	if err != nil || len(value) != ed25519.SignatureSize {
+		return ErrInvalidSignature(signature, "malformed signature")
+	}
+
+	canonical, err := CanonicalEvaluation(fileBytes, signature.Records)
+	if err != nil {
+		return ErrSignatureMismatch(signature, err.Error())
+	}
+	if !ed25519.Verify(publicKey, append([]byte(signaturePrefix), canonical...), value) {
+		return ErrSignatureMismatch(signature, "the content no longer matches the signature")
+	}
+
+	return nil
+}
+
+// TrustedKeys returns the keys trusted to sign an evaluation file: the key
+// pinned for its evaluator in tome.yaml, if any, and the extra keys, e.g.
+// given on the command line. pinned tells whether the evaluator has a pinned
+// key, in which case its evaluations must be signed; see RequireSignedEvaluations.
+func TrustedKeys(config *pkg.TomeConfig, file EvaluationFile, extra []string) (trusted []string, pinned bool) {
+	trusted = append(trusted, extra...)
+	if config == nil {
+		return trusted, false
+	}
+	for _, evaluator := range config.Evaluators {
+		for _, handle := range []string{evaluator.Name, evaluator.Socials.Email, evaluator.Socials.Eth} {
+			if handle != "" && file.EvaluatedBy(handle) {
+				return append(trusted, evaluator.PublicKey), true
+			}
+		}
+	}
+	return trusted, false
+}
+
+// RequireSignedEvaluations checks that every evaluation of the file is covered
+// by a signature made with a trusted key that still matches, either of the
+// whole file or of its records.
+func RequireSignedEvaluations(fileBytes []byte, file EvaluationFile, trusted []string) error {
+	signed := map[string]bool{}
+	wholeFile := false
+	for _, signature := range file.Definition.Meta.Signatures {
+		if VerifySignature(fileBytes, signature, trusted) != nil {
+			continue
+		}
+		if len(signature.Records) == 0 {
+			wholeFile = true
+		}
+		for _, id := range signature.Records {
+			signed[id] = true
+		}
+	}
+	if wholeFile {
+		return nil
+	}
+
+	unsigned := []string{}
+	for _, record := range file.Definition.Evaluations {
+		if !signed[record.ID] {
+			unsigned = append(unsigned, record.ID)
+		}
+	}
+	if len(unsigned) > 0 || len(signed) == 0 {
+		return ErrUnsignedEvaluations(file.EvaluatorName(), unsigned)
+	}
+	return nil
+}
+
+// SignedContent describes what a signature covers.
+func SignedContent(signature pkg.Signature) string {
+	if len(signature.Records) == 0 {
+		return "the whole file"
+	}
+	return "evaluations of " + strings.Join(signature.Records, ", ")
+}
+
+// KeyFingerprint shortens a base64 public key for display.
+func KeyFingerprint(publicKey string) string {
+	if len(publicKey) <= 12 {
+		return publicKey
+	}
+	return publicKey[:12] + "…"
+}
+
+// AddSignature records the signature in the meta of the evaluation file,
+// replacing any signature by the same key of the same content.
+func AddSignature(path string, signature pkg.Signature) error {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	definition := pkg.EvaluationDefinition[pkg.Measurement]{}
+	if err := yaml.Unmarshal(fileBytes, &definition); err != nil {
+		return fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+
+	signatures := []pkg.Signature{}
+	for _, existing := range definition.Meta.Signatures {
+		if existing.PublicKey == signature.PublicKey && SignedContent(existing) == SignedContent(signature) {
+			continue
+		}
+		signatures = append(signatures, existing)
+	}
+	signatures = append(signatures, signature)
+
+	updated, err := replaceSignatures(fileBytes, signatures)
+	if err != nil {
+		return fmt.Errorf("failed to record the signature in %s: %s", path, err)
+	}
+	return writeFilePreservingMode(path, updated)
+}
+
+var metaKeyPattern = regexp.MustCompile(`^meta:\s*(#.*)?$`)
+
+// replaceSignatures rewrites the meta.signatures of an evaluation file,
+// leaving the rest of the file untouched.
+func replaceSignatures(fileBytes []byte, signatures []pkg.Signature) ([]byte, error) {
+	lines := strings.Split(string(fileBytes), "\n")
+
+	start := -1
+	for i, line := range lines {
+		if metaKeyPattern.MatchString(strings.TrimRight(line, " \r")) {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return nil, errors.New("missing meta block")
+	}
+
+	end := len(lines)
+	indent := ""
+	for i := start + 1; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if line[0] != ' ' {
+			end = i
+			break
+		}
+		if indent == "" && !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			indent = line[:len(line)-len(strings.TrimLeft(line, " "))]
+		}
+	}
+	if indent == "" {
+		indent = "  "
+	}
+
+	// Drop the current signatures, up to the next key of the meta block.
+	for i := start + 1; i < end; i++ {
+		if !strings.HasPrefix(lines[i], indent+"signatures:") {
+			continue
+		}
+		j := i + 1
+		for ; j < end; j++ {
+			line := strings.TrimRight(lines[j], "\r")
+			trimmed := strings.TrimLeft(line, " ")
+			depth := len(line) - len(trimmed)
+			if trimmed != "" && (depth < len(indent) || (depth == len(indent) && !strings.HasPrefix(trimmed, "- "))) {
+				break
+			}
+		}
+		for j > i+1 && strings.TrimSpace(lines[j-1]) == "" {
+			j--
+		}
+		lines = append(lines[:i], lines[j:]...)
+		end -= j - i
+		break
+	}
+
+	insert := end
+	for insert > start+1 && strings.TrimSpace(lines[insert-1]) == "" {
+		insert--
+	}
+
+	block := []string{}
+	if len(signatures) > 0 {
+		block = append(block, indent+"signatures:")
+		for _, signature := range signatures {
+			fields := []string{
+				"algorithm: " + signature.Algorithm,
+				"public_key: " + signature.PublicKey,
+				"value: " + signature.Value,
+			}
+			if len(signature.Records) > 0 {
+				fields = append(fields, "records:")
+				for _, id := range signature.Records {
+					fields = append(fields, "  - "+id)
+				}
+			}
+			block = append(block, renderListItem(fields, indent+"  ")...)
+		}
+	}
+
+	updated := append([]string{}, lines[:insert]...)
+	updated = append(updated, block...)
+	updated = append(updated, lines[insert:]...)
+	return []byte(strings.Join(updated, "\n")), nil
+}
+
+// DefaultSigningKeyPath returns where the signing key is stored by default,
+// in the user's configuration directory.
+func DefaultSigningKeyPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tome", "ed25519.pem"), nil
+}
+
+// LoadSigningKey reads a PEM encoded ed25519 private key. When create is set
+// and there is no key yet, a new one is generated and stored, readable only by
+// the user; created tells whether that happened.
+func LoadSigningKey(path string, create bool) (key ed25519.PrivateKey, created bool, err error) {
+	keyBytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && create {
+		key, err = generateSigningKey(path)
+		return key, err == nil, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	block, _ := pem.Decode(keyBytes)
+	if block == nil {
+		return nil, false, fmt.Errorf("%s is not a PEM encoded key", path)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, false, fmt.Errorf("%s is not an ed25519 key", path)
+	}
+	return key, false, nil
+}
+
+func generateSigningKey(path string) (ed25519.PrivateKey, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// PublicKeyOf returns the base64 public key of a signing key, as recorded in signatures.
+func PublicKeyOf(key ed25519.PrivateKey) string {
+	return base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+}
@@ -0,0 +1,219 @@
+package validator
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	"gopkg.in/yaml.v2"
+)
+
+func TestSignEvaluation(t *testing.T) {
+	dir := t.TempDir()
+	key, created, err := LoadSigningKey(filepath.Join(dir, "keys", "ed25519.pem"), true)
+	if err != nil || !created {
+		t.Fatalf("Expected a new signing key, but got %v", err)
+	}
+	if loaded, created, err := LoadSigningKey(filepath.Join(dir, "keys", "ed25519.pem"), true); err != nil || created || !loaded.Equal(key) {
+		t.Fatalf("Expected the stored signing key to be loaded, but got %v", err)
+	}
+
+	path := writeMockFile(t, filepath.Join(dir, "mentor.yaml"), mockWriterEvaluationFile)
+
+	for _, records := range [][]string{nil, {"385d9c24-be5c-5032-a163-7ddab2d35a78"}} {
+		fileBytes, _ := os.ReadFile(path)
+		signature, err := SignEvaluation(fileBytes, key, records)
+		if err != nil {
+			t.Fatalf("SignEvaluation failed: %s", err)
+		}
+		if err := AddSignature(path, signature); err != nil {
+			t.Fatalf("AddSignature failed: %s", err)
+		}
+	}
+
+	file, err := ReadEvaluationFile(path)
+	if err != nil {
+		t.Fatalf("signed evaluation file is not valid: %s", err)
+	}
+	signatures := file.Definition.Meta.Signatures
+	if len(signatures) != 2 || signatures[0].PublicKey != PublicKeyOf(key) || len(signatures[1].Records) != 1 {
+		t.Fatalf("Expected a file and a record signature in meta, but got %+v", signatures)
+	}
+
+	trusted := []string{PublicKeyOf(key)}
+	fileBytes, _ := os.ReadFile(path)
+	for _, signature := range signatures {
+		if err := VerifySignature(fileBytes, signature, trusted); err != nil {
+			t.Errorf("Expected %s to verify, but got %s", SignedContent(signature), err)
+		}
+	}
+	if !strings.Contains(string(fileBytes), "# First week") {
+		t.Error("Expected the comments to be kept")
+	}
+
+	reformatted := []byte(strings.Replace(string(fileBytes), "# First week", "# Reviewed", 1))
+	for _, signature := range signatures {
+		if err := VerifySignature(reformatted, signature, trusted); err != nil {
+			t.Errorf("Expected %s to survive a comment change, but got %s", SignedContent(signature), err)
+		}
+	}
+
+	tampered := []byte(strings.Replace(string(fileBytes), "score: 2", "score: 5", 1))
+	for _, signature := range signatures {
+		if err := VerifySignature(tampered, signature, trusted); err == nil {
+			t.Errorf("Expected %s to no longer match a changed score", SignedContent(signature))
+		}
+	}
+
+	_, other, _ := ed25519.GenerateKey(rand.Reader)
+	for _, signature := range signatures {
+		if err := VerifySignature(fileBytes, signature, []string{PublicKeyOf(other)}); err == nil {
+			t.Errorf("Expected %s to be untrusted without its key", SignedContent(signature))
+		}
+		if err := CheckSignature(fileBytes, signature); err != nil {
+			t.Errorf("Expected %s to still match its content, but got %s", SignedContent(signature), err)
+		}
+	}
+}
+
+const mockSignedEvaluationFile = `tomegg:
+  type: evaluations
+  version: 0.1.0
+  definition: https://protocol.tome.gg/evaluations/0.1.0
+
+meta:
+  evaluator:
+    name: Ada
+    socials:
+      email: ada@example.com
+  dimensions:
+    - alias: focus
+      name: focus
+      version: 0.1.0
+      definition: https://protocol.tome.gg/dimensions/focus/0.1.0
+
+evaluations:
+  - id: 385d9c24-be5c-5032-a163-7ddab2d35a78
+    measurements:
+      - dimension: focus
+        score: 2
+  - id: a7fd6a39-b857-585f-9233-85cec2027477
+    measurements:
+      - dimension: focus
+        score: 4
+`
+
+func TestRecordSignatureCoversEvaluator(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	records := []string{"385d9c24-be5c-5032-a163-7ddab2d35a78"}
+	signature, err := SignEvaluation([]byte(mockSignedEvaluationFile), key, records)
+	if err != nil {
+		t.Fatalf("SignEvaluation failed: %s", err)
+	}
+
+	for name, tampered := range map[string]string{
+		"evaluator": strings.Replace(mockSignedEvaluationFile, "name: Ada", "name: Grace", 1),
+		"subtype":   strings.Replace(mockSignedEvaluationFile, "version: 0.1.0\n  definition", "subtype: meta\n  version: 0.1.0\n  definition", 1),
+	} {
+		if err := CheckSignature([]byte(tampered), signature); err == nil {
+			t.Errorf("Expected the record signature to no longer match a changed %s", name)
+		}
+	}
+}
+
+func TestRequireSignedEvaluations(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	config := &pkg.TomeConfig{Evaluators: []pkg.PinnedEvaluator{
+		{Evaluator: pkg.Evaluator{Socials: pkg.EvaluatorSocials{Email: "ADA@example.com"}}, PublicKey: PublicKeyOf(key)},
+	}}
+
+	sign := func(fileBytes []byte, records []string) []byte {
+		path := writeMockFile(t, filepath.Join(t.TempDir(), "ada.yaml"), string(fileBytes))
+		signature, err := SignEvaluation(fileBytes, key, records)
+		if err != nil {
+			t.Fatalf("SignEvaluation failed: %s", err)
+		}
+		if err := AddSignature(path, signature); err != nil {
+			t.Fatalf("AddSignature failed: %s", err)
+		}
+		signed, _ := os.ReadFile(path)
+		return signed
+	}
+	require := func(fileBytes []byte) error {
+		file := EvaluationFile{Filepath: "evaluations/ada.yaml"}
+		if err := yaml.Unmarshal(fileBytes, &file.Definition); err != nil {
+			t.Fatalf("failed to parse the evaluation file: %s", err)
+		}
+		trusted, pinned := TrustedKeys(config, file, nil)
+		if !pinned || len(trusted) != 1 {
+			t.Fatalf("Expected Ada's key to be pinned by email, but got %v", trusted)
+		}
+		return RequireSignedEvaluations(fileBytes, file, trusted)
+	}
+
+	unsigned := []byte(mockSignedEvaluationFile)
+	if err := require(unsigned); err == nil {
+		t.Error("Expected an error for unsigned evaluations by a pinned evaluator")
+	}
+
+	partly := sign(unsigned, []string{"385d9c24-be5c-5032-a163-7ddab2d35a78"})
+	if err := require(partly); err == nil || !strings.Contains(err.Error(), "a7fd6a39-b857-585f-9233-85cec2027477") {
+		t.Errorf("Expected an error naming the unsigned evaluation, but got %v", err)
+	}
+
+	if err := require(sign(unsigned, nil)); err != nil {
+		t.Errorf("Expected a whole file signature to cover every evaluation, but got %s", err)
+	}
+
+	_, other, _ := ed25519.GenerateKey(rand.Reader)
+	forged, err := SignEvaluation(unsigned, other, nil)
+	if err != nil {
+		t.Fatalf("SignEvaluation failed: %s", err)
+	}
+	path := writeMockFile(t, filepath.Join(t.TempDir(), "ada.yaml"), mockSignedEvaluationFile)
+	if err := AddSignature(path, forged); err != nil {
+		t.Fatalf("AddSignature failed: %s", err)
+	}
+	forgedBytes, _ := os.ReadFile(path)
+	if err := require(forgedBytes); err == nil {
+		t.Error("Expected an error for evaluations signed with another key than the pinned one")
+	}
+}
+
+func TestEvaluationValidatorRequiresPinnedSignatures(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	root := t.TempDir()
+	writeMockFile(t, filepath.Join(root, "tome.yaml"), "evaluators:\n  - name: Ada\n    public_key: "+PublicKeyOf(key)+"\n")
+	path := writeMockFile(t, filepath.Join(root, "evaluations", "ada.yaml"), mockSignedEvaluationFile)
+
+	validate := func() error {
+		config, err := pkg.LoadConfig(root)
+		if err != nil {
+			t.Fatalf("failed to load config: %s", err)
+		}
+		plan := pkg.NewValidationPlan(nil, nil)
+		plan.Config = config
+		plan.Metadata["registeredTraining"] = []string{"385d9c24-be5c-5032-a163-7ddab2d35a78", "a7fd6a39-b857-585f-9233-85cec2027477"}
+		plan.Metadata["validTraining"] = plan.Metadata["registeredTraining"]
+		return NewEvaluationValidator(plan).File(&pkg.File{Filepath: path})
+	}
+
+	if err := validate(); err == nil {
+		t.Error("Expected an error for unsigned evaluations by a pinned evaluator")
+	}
+
+	signature, err := SignEvaluation([]byte(mockSignedEvaluationFile), key, nil)
+	if err != nil {
+		t.Fatalf("SignEvaluation failed: %s", err)
+	}
+	if err := AddSignature(path, signature); err != nil {
+		t.Fatalf("AddSignature failed: %s", err)
+	}
+	if err := validate(); err != nil {
+		t.Errorf("Expected evaluations signed with the pinned key to be valid, but got %s", err)
+	}
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
-	"gopkg.in/yaml.v2"
 )
 
 type dailyStandUpValidator struct {
@@ -39,8 +38,7 @@ func (m *dailyStandUpValidator) File(dir *pkg.File) error {
 		return err
 	}
 
-	var result = pkg.TrainingDefinition[pkg.DSUReport]{}
-	err = yaml.Unmarshal(fileBytes, &result)
+	result, err := decodeDSUDefinition(fileBytes)
 	
 	if err != nil {
 		return err
@@ -54,7 +52,10 @@ func (m *dailyStandUpValidator) File(dir *pkg.File) error {
 		return ErrUnsupportedVersion
 	}
 
-	if result.Meta.Format.Type != "dsu" {
+	if result.Meta.Format.Type != pkg.FormatDSU {
+		if IsTrainingFormat(result.Meta.Format.Type) {
+			return nil // Validated by the training format validator
+		}
 		return ErrUnsupportedFormat
 	}
 
@@ -76,14 +77,47 @@ func (m *dailyStandUpValidator) File(dir *pkg.File) error {
 		m.log.Warnf("empty training set")
 	}
 
+	for _, tag := range result.Meta.Tags {
+		if !m.plan.Config.IsAllowedTag(tag) {
+			return ErrUnknownFileTag(tag)
+		}
+	}
+
+	fields, err := ResolveFieldDefinitions(m.plan.Config.Root(), result.Meta.Format.Fields, result.Meta.Format.Schema)
+	if err != nil {
+		return err
+	}
+
+	parser, err := m.plan.Config.DatetimeParser()
+	if err != nil {
+		return fmt.Errorf("invalid datetime config: %s", err)
+	}
+
 	for _, e := range result.Content {
 		m.plan.Metadata["registeredTraining"] = append(m.plan.Metadata["registeredTraining"].([]string), e.ID)
 		m.log.WithField("training", e.ID).Debugf("registered training")
 
+		if err := e.ParseDatetime(parser); err != nil {
+			return err
+		}
+
 		err := m.validateDSUEntry(e)
 		if err != nil {
 			return err
 		}
+
+		err = ValidateDSUTags(m.plan.Config, e)
+		if err != nil {
+			return err
+		}
+
+		undeclared, err := ValidateDSUFields(fields, e)
+		if err != nil {
+			return err
+		}
+		for _, name := range undeclared {
+			m.log.WithField("training", e.ID).Warnf("field %s is not declared in meta.format.fields", name)
+		}
 		m.plan.Metadata["validTraining"] = append(m.plan.Metadata["validTraining"].([]string), e.ID)
 	}
 
@@ -97,6 +131,11 @@ func (m *dailyStandUpValidator) File(dir *pkg.File) error {
 
 
 func (m *dailyStandUpValidator) validateDSUEntry(e pkg.DSUReport) error {
+	return ValidateDSUEntry(e)
+}
+
+// ValidateDSUEntry checks a single DSU entry against the DSU rules.
+func ValidateDSUEntry(e pkg.DSUReport) error {
 	if strings.TrimSpace(e.DoingToday) == "" {
 		return ErrRequiredField(e.ID, "doing_today")
 	}
@@ -112,6 +151,16 @@ func (m *dailyStandUpValidator) validateDSUEntry(e pkg.DSUReport) error {
 	return nil
 }
 
+// ValidateDSUTags checks the entry's own tags against the tag vocabulary of tome.yaml.
+func ValidateDSUTags(config *pkg.TomeConfig, e pkg.DSUReport) error {
+	for _, tag := range e.Tags {
+		if !config.IsAllowedTag(tag) {
+			return ErrUnknownTag(e.ID, tag)
+		}
+	}
+	return nil
+}
+
 // Validate defines the process for validating a certain directory.
 func (m *dailyStandUpValidator) Directory(dir *pkg.Directory) error {
 	if strings.Contains(dir.Path, "training") == false {
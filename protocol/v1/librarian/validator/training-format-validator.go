@@ -0,0 +1,253 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+	"gopkg.in/yaml.v2"
+)
+
+// trainingFormat validates the content of a training file of a given format,
+// returning the IDs of the registered entries and of the valid ones.
+type trainingFormat func(config *pkg.TomeConfig, fileBytes []byte) (registered []string, valid []string, err error)
+
+// trainingFormats maps the meta.format.type of a training file to the validator
+// of its entries. DSU files are handled by the DSU validator.
+var trainingFormats = map[string]trainingFormat{
+	pkg.FormatRetrospective:  validateTrainingEntries(ValidateRetrospectiveEntry),
+	pkg.FormatLearningLog:    validateTrainingEntries(ValidateLearningLogEntry),
+	pkg.FormatPairingSession: validateTrainingEntries(ValidatePairingSessionEntry),
+}
+
+// IsTrainingFormat returns true if the meta.format.type is a supported training format.
+func IsTrainingFormat(format string) bool {
+	if format == pkg.FormatDSU {
+		return true
+	}
+	_, ok := trainingFormats[format]
+	return ok
+}
+
+type trainingFormatValidator struct {
+	log  *logrus.Entry
+	plan *pkg.ValidationPlan
+}
+
+// File implements Validator
+func (m *trainingFormatValidator) File(dir *pkg.File) error {
+	if strings.Contains(dir.Filepath, "training") == false {
+		return nil
+	}
+
+	if ext := filepath.Ext(dir.Filepath); ext != ".yaml" && ext != ".yml" {
+		return nil
+	}
+
+	fileBytes, err := os.ReadFile(dir.Filepath)
+	if err != nil {
+		return err
+	}
+
+	var header = pkg.TrainingDefinition[yaml.MapSlice]{}
+	err = yaml.Unmarshal(fileBytes, &header)
+	if err != nil {
+		return err
+	}
+
+	if header.Tomegg.Type != "training" {
+		return nil
+	}
+
+	validate, ok := trainingFormats[header.Meta.Format.Type]
+	if !ok {
+		// DSU files are handled by the DSU validator, which also reports
+		// unsupported formats in files it picks up.
+		if header.Meta.Format.Type == pkg.FormatDSU || strings.Contains(dir.Filepath, "dsu") {
+			return nil
+		}
+		// Training files of other formats were never validated, and still pass.
+		m.log.
+			WithField("file", dir.Filepath).
+			WithField("type", header.Meta.Format.Type).
+			Warnf("skipping training file of an unsupported format")
+		return nil
+	}
+
+	if header.Tomegg.Version != "0.1.0" {
+		return ErrUnsupportedVersion
+	}
+
+	if header.Meta.Format.Version != "0.1.0" {
+		return ErrUnsupportedVersion
+	}
+
+	expectedTomeggDef := fmt.Sprintf("https://protocol.tome.gg/%s/%s", header.Tomegg.Type, header.Tomegg.Version)
+	if header.Tomegg.Definition != expectedTomeggDef {
+		return ErrMismatchedTomeggDefinition(expectedTomeggDef, header.Tomegg.Definition)
+	}
+
+	expectedFormatDef := fmt.Sprintf("https://protocol.tome.gg/formats/%s/%s", header.Meta.Format.Type, header.Meta.Format.Version)
+	if header.Meta.Format.Definition != expectedFormatDef {
+		return ErrMismatchedFormatDefinition(header.Meta.Format.Type, expectedFormatDef, header.Meta.Format.Definition)
+	}
+
+	if len(header.Content) == 0 {
+		m.log.WithField("file", dir.Filepath).Warnf("empty training set")
+	}
+
+	for _, tag := range header.Meta.Tags {
+		if !m.plan.Config.IsAllowedTag(tag) {
+			return ErrUnknownFileTag(tag)
+		}
+	}
+
+	registered, valid, err := validate(m.plan.Config, fileBytes)
+	m.plan.Metadata["registeredTraining"] = append(m.plan.Metadata["registeredTraining"].([]string), registered...)
+	m.plan.Metadata["validTraining"] = append(m.plan.Metadata["validTraining"].([]string), valid...)
+	if err != nil {
+		return err
+	}
+
+	m.log.
+		WithField("type", header.Meta.Format.Type).
+		Infof("ok")
+
+	return nil
+}
+
+// validateTrainingEntries creates a training format validating the datetime
+// of each entry of the file, the entry itself, and its tags against the tag
+// vocabulary of tome.yaml.
+func validateTrainingEntries[E pkg.TrainingEntry, P interface {
+	*E
+	pkg.DatedEntry
+}](validateEntry func(E) error) trainingFormat {
+	return func(config *pkg.TomeConfig, fileBytes []byte) ([]string, []string, error) {
+		parser, err := config.DatetimeParser()
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid datetime config: %s", err)
+		}
+
+		var result = pkg.TrainingDefinition[E]{}
+		if err := yaml.Unmarshal(fileBytes, &result); err != nil {
+			return nil, nil, err
+		}
+
+		registered := []string{}
+		valid := []string{}
+		for _, e := range result.Content {
+			registered = append(registered, e.EntryID())
+
+			if err := P(&e).ParseDatetime(parser); err != nil {
+				return registered, valid, err
+			}
+
+			if err := validateEntry(e); err != nil {
+				return registered, valid, err
+			}
+
+			for _, tag := range e.EntryTags() {
+				if !config.IsAllowedTag(tag) {
+					return registered, valid, ErrUnknownTag(e.EntryID(), tag)
+				}
+			}
+			valid = append(valid, e.EntryID())
+		}
+
+		return registered, valid, nil
+	}
+}
+
+// validateEntryBase checks the fields shared by every training entry.
+func validateEntryBase(id string, datetime string) error {
+	if strings.TrimSpace(id) == "" {
+		return ErrRequiredField(id, "id")
+	}
+	if strings.TrimSpace(datetime) == "" {
+		return ErrRequiredField(id, "datetime")
+	}
+	return nil
+}
+
+// validateDuration checks that a duration field, when set, reads like 45m or 1h30m.
+func validateDuration(id string, field string, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	if d, err := time.ParseDuration(strings.TrimSpace(value)); err != nil || d <= 0 {
+		return fmt.Errorf("invalid %s %q for content entry %s: expected a duration such as 45m or 1h30m", field, value, id)
+	}
+	return nil
+}
+
+// ValidateRetrospectiveEntry checks a single weekly retrospective entry.
+func ValidateRetrospectiveEntry(e pkg.RetrospectiveEntry) error {
+	if err := validateEntryBase(e.ID, e.DatetimeRaw); err != nil {
+		return err
+	}
+	if strings.TrimSpace(e.WentWell) == "" {
+		return ErrRequiredField(e.ID, "went_well")
+	}
+	if strings.TrimSpace(e.ToImprove) == "" {
+		return ErrRequiredField(e.ID, "to_improve")
+	}
+	if strings.TrimSpace(e.Actions) == "" {
+		return ErrRequiredField(e.ID, "actions")
+	}
+	return nil
+}
+
+// ValidateLearningLogEntry checks a single learning log entry.
+func ValidateLearningLogEntry(e pkg.LearningLogEntry) error {
+	if err := validateEntryBase(e.ID, e.DatetimeRaw); err != nil {
+		return err
+	}
+	if strings.TrimSpace(e.Resource) == "" {
+		return ErrRequiredField(e.ID, "resource")
+	}
+	if strings.TrimSpace(e.TimeSpent) == "" {
+		return ErrRequiredField(e.ID, "time_spent")
+	}
+	if err := validateDuration(e.ID, "time_spent", e.TimeSpent); err != nil {
+		return err
+	}
+	if strings.TrimSpace(e.Takeaways) == "" {
+		return ErrRequiredField(e.ID, "takeaways")
+	}
+	return nil
+}
+
+// ValidatePairingSessionEntry checks a single pairing session entry.
+func ValidatePairingSessionEntry(e pkg.PairingSessionEntry) error {
+	if err := validateEntryBase(e.ID, e.DatetimeRaw); err != nil {
+		return err
+	}
+	if len(e.Partners) == 0 {
+		return ErrRequiredField(e.ID, "partners")
+	}
+	if strings.TrimSpace(e.Topic) == "" {
+		return ErrRequiredField(e.ID, "topic")
+	}
+	return validateDuration(e.ID, "duration", e.Duration)
+}
+
+// Directory implements Validator
+func (m *trainingFormatValidator) Directory(dir *pkg.Directory) error {
+	return nil
+}
+
+// NewTrainingFormatValidator creates the validator of the training formats
+// other than DSUs: retrospectives, learning logs and pairing sessions.
+func NewTrainingFormatValidator(plan *pkg.ValidationPlan) Validator {
+	return &trainingFormatValidator{
+		log: logrus.WithFields(logrus.Fields{
+			"validator": "training",
+		}),
+		plan: plan,
+	}
+}
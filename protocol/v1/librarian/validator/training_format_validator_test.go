@@ -0,0 +1,98 @@
+package validator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tome-gg/librarian/protocol/v1/librarian/pkg"
+)
+
+const mockLearningLogFile = `tomegg:
+  type: training
+  version: 0.1.0
+  definition: https://protocol.tome.gg/training/0.1.0
+
+meta:
+  format:
+    type: learning_log
+    version: 0.1.0
+    definition: https://protocol.tome.gg/formats/learning_log/0.1.0
+
+content:
+  - id: 0b0c8d8e-5c1f-4c55-9a4e-3f4f0e0d7a11
+    datetime: 2024-07-01
+    resource: The Go Programming Language, chapter 8
+    time_spent: 1h30m
+    takeaways: |
+      - Unbuffered channels synchronize sender and receiver
+`
+
+func TestTrainingFormatValidator(t *testing.T) {
+	path := writeMockFile(t, filepath.Join(t.TempDir(), "training", "learning-log.yaml"), mockLearningLogFile)
+
+	plan := pkg.NewValidationPlan(nil, nil)
+	if err := NewTrainingFormatValidator(plan).File(&pkg.File{Filepath: path}); err != nil {
+		t.Fatalf("Expected the learning log to be valid, but got %s", err)
+	}
+	if !plan.IsValid("0b0c8d8e-5c1f-4c55-9a4e-3f4f0e0d7a11") {
+		t.Error("Expected the learning log entry to be registered for evaluations")
+	}
+}
+
+func TestTrainingFormatValidatorSkipsUnsupportedFormats(t *testing.T) {
+	content := strings.ReplaceAll(mockLearningLogFile, "learning_log", "reading_list")
+	path := writeMockFile(t, filepath.Join(t.TempDir(), "training", "reading-list.yaml"), content)
+
+	plan := pkg.NewValidationPlan(nil, nil)
+	if err := NewTrainingFormatValidator(plan).File(&pkg.File{Filepath: path}); err != nil {
+		t.Fatalf("Expected the training file of an unsupported format to be skipped, but got %s", err)
+	}
+	if plan.IsValid("0b0c8d8e-5c1f-4c55-9a4e-3f4f0e0d7a11") {
+		t.Error("Expected the entries of an unsupported format not to be registered")
+	}
+}
+
+func TestTrainingFormatValidatorTags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "training", "learning-log.yaml")
+
+	testCases := []struct {
+		name  string
+		tags  string
+		valid bool
+	}{
+		{name: "known tag", tags: "[go]", valid: true},
+		{name: "tag in another case", tags: "[Go]", valid: true},
+		{name: "unknown tag", tags: "[rust]", valid: false},
+	}
+
+	for _, tc := range testCases {
+		content := strings.Replace(mockLearningLogFile, "meta:\n", "meta:\n  tags: "+tc.tags+"\n", 1)
+		writeMockFile(t, path, content)
+
+		plan := pkg.NewValidationPlan(nil, nil)
+		plan.Config.Tags = []string{"go"}
+		err := NewTrainingFormatValidator(plan).File(&pkg.File{Filepath: path})
+		if tc.valid && err != nil {
+			t.Errorf("%s: expected the file to be valid, but got %s", tc.name, err)
+		}
+		if !tc.valid && (err == nil || !strings.Contains(err.Error(), "meta.tags")) {
+			t.Errorf("%s: expected an unknown file tag error, but got %v", tc.name, err)
+		}
+	}
+}
+
+func TestValidateTrainingEntries(t *testing.T) {
+	if err := ValidateRetrospectiveEntry(pkg.RetrospectiveEntry{ID: "r1", DatetimeRaw: "2024-07-05", WentWell: "- Shipped", ToImprove: "- Estimates"}); err == nil {
+		t.Error("Expected a retrospective without actions to be invalid")
+	}
+	if err := ValidateLearningLogEntry(pkg.LearningLogEntry{ID: "l1", DatetimeRaw: "2024-07-05", Resource: "Book", TimeSpent: "an hour", Takeaways: "- Read"}); err == nil {
+		t.Error("Expected a learning log with an invalid time_spent to be invalid")
+	}
+	if err := ValidatePairingSessionEntry(pkg.PairingSessionEntry{ID: "p1", DatetimeRaw: "2024-07-05", Topic: "Migrations"}); err == nil {
+		t.Error("Expected a pairing session without partners to be invalid")
+	}
+	if err := ValidatePairingSessionEntry(pkg.PairingSessionEntry{ID: "p1", DatetimeRaw: "2024-07-05", Partners: []string{"Darren"}, Topic: "Migrations", Duration: "45m"}); err != nil {
+		t.Errorf("Expected the pairing session to be valid, but got %s", err)
+	}
+}
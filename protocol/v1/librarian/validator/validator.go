@@ -22,6 +22,7 @@ func registerValidators(_ *pkg.Directory, plan *pkg.ValidationPlan) error {
 
 	validators = []Validator{
 		NewDSUValidator(plan),
+		NewTrainingFormatValidator(plan),
 		NewEvaluationValidator(plan),
 	}
 	return nil
@@ -47,6 +48,16 @@ func Init(root *pkg.Directory) *pkg.ValidationPlan {
 
 	plan := pkg.NewValidationPlan(dirs, files)
 
+	config, err := pkg.LoadConfig(root.Path)
+	if err != nil {
+		logrus.WithField("path", root.Path).Warnf("failed to load config: %s", err)
+	}
+	plan.Config = config
+
+	if _, err := config.DatetimeParser(); err != nil {
+		logrus.WithField("path", root.Path).Warnf("invalid datetime config: %s", err)
+	}
+
 	registerValidators(root, plan)
 
 	return plan